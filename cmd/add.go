@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -9,29 +11,63 @@ import (
 	"github.com/omgitsads/gh-pair/internal/github"
 )
 
+var addBranch bool
+var addTeam string
+
 var addCmd = &cobra.Command{
-	Use:   "add <@username>",
+	Use:   "add <@username> | --team <org/slug>",
 	Short: "Add a pair by GitHub username",
 	Long: `Add a GitHub user as a co-author for your commits.
 The user's name and email will be fetched from GitHub.
 
+With --branch, the pair only applies to commits on the current branch.
+A branch's pairs replace the default pairs while it is checked out.
+
+People in your roster, such as those imported with 'gh pair import
+--from', can be added by username or alias without a GitHub lookup.
+
+With --team, every member of a GitHub team except you is added.
+
 Examples:
   gh pair add @octocat
-  gh pair add octocat`,
-	Args: cobra.ExactArgs(1),
+  gh pair add octocat
+  gh pair add --branch @octocat
+  gh pair add jd
+  gh pair add --team my-org/my-team`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if addTeam != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := checkGitRepo(); err != nil {
 			return err
 		}
 
+		if addTeam != "" {
+			return addTeamMembers(addTeam)
+		}
+
 		username := args[0]
 
-		// Lookup user on GitHub
-		pair, err := github.LookupUser(username)
+		// Use the roster for aliases and people without a GitHub account,
+		// otherwise lookup user on GitHub
+		pair, err := lookupPair(username)
 		if err != nil {
 			return err
 		}
 
+		if addBranch {
+			branch, err := config.AddBranchPair(*pair)
+			if err != nil {
+				return fmt.Errorf("failed to add pair: %w", err)
+			}
+
+			fmt.Printf("✓ Added to %s: %s <%s>\n", branch, pair.Name, pair.Email)
+			return nil
+		}
+
 		// Add to config
 		if err := config.AddPair(*pair); err != nil {
 			return fmt.Errorf("failed to add pair: %w", err)
@@ -42,6 +78,70 @@ Examples:
 	},
 }
 
+// addTeamMembers adds every member of a team except the current user.
+func addTeamMembers(team string) error {
+	org, slug, ok := strings.Cut(team, "/")
+	if !ok || org == "" || slug == "" {
+		return fmt.Errorf("invalid team %q: expected org/slug", team)
+	}
+
+	members, err := github.GetTeamMembers(org, slug)
+	if err != nil {
+		return err
+	}
+
+	currentUser, _ := github.GetAuthenticatedUser()
+
+	var pairs []config.Pair
+	for _, m := range members {
+		if strings.EqualFold(m.Username, currentUser) {
+			continue
+		}
+		pair, err := github.LookupUser(m.Username)
+		if err != nil {
+			return err
+		}
+		pairs = append(pairs, *pair)
+	}
+
+	var added int
+	where := ""
+	if addBranch {
+		var branch string
+		branch, added, err = config.AddBranchPairs(pairs)
+		where = " to " + branch
+	} else {
+		added, err = config.AddPairs(pairs)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add pairs: %w", err)
+	}
+
+	fmt.Printf("✓ Added %d of %d members of %s%s\n", added, len(pairs), team, where)
+	for _, p := range pairs {
+		fmt.Printf("  @%-20s %s <%s>\n", p.Username, p.Name, p.Email)
+	}
+	return nil
+}
+
+// lookupPair returns the roster entry with the given username or alias,
+// falling back to looking the user up on GitHub. A username only guessed
+// from an imported email is used when GitHub has no such user.
+func lookupPair(username string) (*config.Pair, error) {
+	if pair, ok := config.ResolveRoster(username); ok {
+		return &pair, nil
+	}
+	pair, err := github.LookupUser(username)
+	if errors.Is(err, github.ErrUserNotFound) {
+		if guess, ok := config.GuessRoster(username); ok {
+			return &guess, nil
+		}
+	}
+	return pair, err
+}
+
 func init() {
 	rootCmd.AddCommand(addCmd)
+	addCmd.Flags().BoolVar(&addBranch, "branch", false, "Only add the pair on the current branch")
+	addCmd.Flags().StringVar(&addTeam, "team", "", "Add every member of a GitHub team (org/slug)")
 }
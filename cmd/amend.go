@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+	"github.com/omgitsads/gh-pair/internal/git"
+	"github.com/omgitsads/gh-pair/internal/github"
+	"github.com/omgitsads/gh-pair/internal/hook"
+)
+
+var amendPairs []string
+var amendForce bool
+
+var amendCmd = &cobra.Command{
+	Use:   "amend [<rev-range>]",
+	Short: "Add co-author trailers to existing commits",
+	Long: `Add Co-Authored-By trailers to commits that were made without them.
+
+With no arguments, HEAD is amended. A single revision rewrites every
+commit after it (<rev>..HEAD), and a range such as main..HEAD rewrites
+the commits it selects. Later commits are replayed on top, keeping
+their content and authorship.
+
+The current pairs are credited unless --pair is given. Commits that are
+already on the upstream branch are refused unless --force is used, as
+are all commits on a branch with no upstream.
+
+Examples:
+  gh pair amend
+  gh pair amend HEAD~3
+  gh pair amend origin/main..HEAD --pair @octocat`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		pairs, err := amendCoAuthors()
+		if err != nil {
+			return err
+		}
+		if len(pairs) == 0 {
+			return fmt.Errorf("no pairs configured; use 'gh pair add' or --pair")
+		}
+
+		targets, err := amendTargets(args)
+		if err != nil {
+			return err
+		}
+		if len(targets) == 0 {
+			fmt.Println("No commits to amend")
+			return nil
+		}
+
+		if !amendForce {
+			// Without an upstream there's no telling whether the commits
+			// have been pushed, so rewriting them needs --force
+			upstream := git.Upstream()
+			if upstream == "" {
+				return fmt.Errorf("the current branch has no upstream to check whether these commits were pushed; use --force to rewrite them")
+			}
+			for _, t := range targets {
+				if git.IsAncestor(t, upstream) {
+					return fmt.Errorf("commit %.7s is already on %s; use --force to rewrite it", t, upstream)
+				}
+			}
+		}
+
+		changed, err := git.RewriteMessages(targets, func(message string) (string, error) {
+			return hook.AddTrailers(message, pairs)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to amend commits: %w", err)
+		}
+
+		if changed == 0 {
+			fmt.Println("✓ Commits already credit these co-authors")
+			return nil
+		}
+
+		fmt.Printf("✓ Amended %d commit(s) with:\n", changed)
+		for _, p := range pairs {
+			fmt.Printf("  %s <%s>\n", p.Name, p.Email)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(amendCmd)
+	amendCmd.Flags().StringSliceVarP(&amendPairs, "pair", "p", nil, "Co-author to credit instead of the current pairs (repeatable)")
+	amendCmd.Flags().BoolVar(&amendForce, "force", false, "Rewrite commits even if they may already have been pushed")
+}
+
+// amendCoAuthors returns the pairs given with --pair, or the current pairs.
+func amendCoAuthors() ([]config.Pair, error) {
+	if len(amendPairs) == 0 {
+		pairs, err := config.LoadPairs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load pairs: %w", err)
+		}
+		return pairs.Pairs, nil
+	}
+
+	pairs := make([]config.Pair, 0, len(amendPairs))
+	for _, username := range amendPairs {
+		pair, err := github.LookupUser(username)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, *pair)
+	}
+	return pairs, nil
+}
+
+// amendTargets resolves the commits selected by the optional rev-range argument.
+func amendTargets(args []string) ([]string, error) {
+	if len(args) == 0 {
+		head, err := git.ResolveRev("HEAD")
+		if err != nil {
+			return nil, err
+		}
+		return []string{head}, nil
+	}
+
+	revRange := args[0]
+	if !strings.Contains(revRange, "..") {
+		revRange += "..HEAD"
+	}
+	return git.RevList(revRange)
+}
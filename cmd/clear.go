@@ -8,15 +8,30 @@ import (
 	"github.com/omgitsads/gh-pair/internal/config"
 )
 
+var clearBranch bool
+
 var clearCmd = &cobra.Command{
 	Use:   "clear",
 	Short: "Remove all pairs",
-	Long:  `Clear all co-authors from the current repository.`,
+	Long: `Clear all co-authors from the current repository.
+
+With --branch, only the current branch's pairs are removed and the
+default pairs apply to it again.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := checkGitRepo(); err != nil {
 			return err
 		}
 
+		if clearBranch {
+			branch, err := config.ClearBranchPairs()
+			if err != nil {
+				return fmt.Errorf("failed to clear pairs: %w", err)
+			}
+
+			fmt.Printf("✓ Pairs cleared for %s; default pairs apply\n", branch)
+			return nil
+		}
+
 		if err := config.ClearPairs(); err != nil {
 			return fmt.Errorf("failed to clear pairs: %w", err)
 		}
@@ -28,4 +43,5 @@ var clearCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(clearCmd)
+	clearCmd.Flags().BoolVar(&clearBranch, "branch", false, "Only clear the current branch's pairs")
 }
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+)
+
+var driveReset bool
+
+var driveCmd = &cobra.Command{
+	Use:   "drive [<@username>]",
+	Short: "Make a pair the author of new commits",
+	Long: `Switch the commit author to the pair at the keyboard.
+
+Sets the repository's user.name and user.email to the given user and
+adds you as a co-author instead, so commits are credited correctly when
+you swap keyboards on one machine. Use --reset to take the keyboard back.
+Without arguments, shows who is driving.
+
+Examples:
+  gh pair drive @octocat
+  gh pair drive --reset`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		if driveReset {
+			session, err := config.ResetDriver()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("✓ %s is authoring again; @%s is a co-author\n",
+				session.Owner.Name, session.Driver.Username)
+			return nil
+		}
+
+		if len(args) == 0 {
+			session, err := config.LoadDriver()
+			if err != nil {
+				return fmt.Errorf("failed to load driver: %w", err)
+			}
+			if session == nil {
+				fmt.Println("No driver set; commits are authored by you")
+				return nil
+			}
+			fmt.Printf("Driver: %s <%s>\n", session.Driver.Name, session.Driver.Email)
+			return nil
+		}
+
+		driver, err := resolvePair(args[0])
+		if err != nil {
+			return err
+		}
+
+		owner, err := currentIdentity()
+		if err != nil {
+			return err
+		}
+
+		session, err := config.Drive(*driver, owner)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ %s <%s> is now driving\n", session.Driver.Name, session.Driver.Email)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(driveCmd)
+	driveCmd.Flags().BoolVar(&driveReset, "reset", false, "Restore your own identity as the commit author")
+}
+
+// resolvePair returns the current or recent pair with the given username,
+// falling back to the roster and then looking the user up on GitHub.
+func resolvePair(username string) (*config.Pair, error) {
+	username = strings.TrimPrefix(username, "@")
+
+	pairs, err := config.LoadPairs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pairs: %w", err)
+	}
+	for _, p := range pairs.Pairs {
+		if strings.EqualFold(p.Username, username) {
+			return &p, nil
+		}
+	}
+
+	if recent, err := config.LoadRecent(); err == nil {
+		for _, p := range recent.Recent {
+			if strings.EqualFold(p.Username, username) {
+				return &p, nil
+			}
+		}
+	}
+
+	return lookupPair(username)
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+)
+
+var editName string
+var editEmail string
+
+var editCmd = &cobra.Command{
+	Use:   "edit <@username>",
+	Short: "Change a pair's name or email",
+	Long: `Change the name or email a pair is credited with, for example to fix
+a name or use a work email instead of the one on their GitHub profile.
+
+The change applies to the current pairs and the recent list, and is
+remembered in ~/.config/gh-pair/roster.json so adding the user again in
+any repository uses it.
+
+Examples:
+  gh pair edit @octocat --email octocat@work.example.com
+  gh pair edit @octocat --name "Mona Lisa Octocat"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		if editName == "" && editEmail == "" {
+			return errors.New("nothing to change; use --name and/or --email")
+		}
+
+		pair, err := resolvePair(args[0])
+		if err != nil {
+			return err
+		}
+
+		if editName != "" {
+			pair.Name = editName
+		}
+		if editEmail != "" {
+			pair.Email = editEmail
+		}
+
+		if err := config.EditPair(*pair); err != nil {
+			return fmt.Errorf("failed to edit pair: %w", err)
+		}
+
+		fmt.Printf("✓ Updated: %s <%s>\n", pair.Name, pair.Email)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(editCmd)
+	editCmd.Flags().StringVar(&editName, "name", "", "Name to credit the user with")
+	editCmd.Flags().StringVar(&editEmail, "email", "", "Email to credit the user with")
+}
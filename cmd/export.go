@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+	"github.com/omgitsads/gh-pair/internal/settings"
+)
+
+var exportOutput string
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export pairs, recent pairs, roster and settings",
+	Long: `Write this repository's pairs, branch pairs and recent pairs, together
+with your roster of name and email overrides and your settings, to a
+portable file. Load it on another machine or in another repository with
+'gh pair import'.
+
+Examples:
+  gh pair export > pairs-backup.json
+  gh pair export -o pairs-backup.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		bundle, err := config.Export()
+		if err != nil {
+			return fmt.Errorf("failed to export: %w", err)
+		}
+
+		cfg := settings.Load()
+		bundle.Settings = &config.BundleSettings{Theme: cfg.Theme, RecentSize: cfg.RecentSize}
+
+		data, err := json.MarshalIndent(bundle, "", "  ")
+		if err != nil {
+			return err
+		}
+		data = append(data, '\n')
+
+		if exportOutput == "" || exportOutput == "-" {
+			_, err = os.Stdout.Write(data)
+			return err
+		}
+
+		if err := os.WriteFile(exportOutput, data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", exportOutput, err)
+		}
+
+		fmt.Printf("✓ Exported to %s\n", exportOutput)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Write to a file instead of stdout")
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/omgitsads/gh-pair/internal/authors"
+	"github.com/omgitsads/gh-pair/internal/config"
+	"github.com/omgitsads/gh-pair/internal/settings"
+)
+
+var importReplace bool
+var importFrom string
+
+var importCmd = &cobra.Command{
+	Use:   "import [<file>]",
+	Short: "Import a file written by 'gh pair export' or another pairing tool",
+	Long: `Load pairs, branch pairs, recent pairs, roster and settings from a file
+written by 'gh pair export'. Use - to read from stdin.
+
+With --from, import the authors of another pairing tool into your roster
+instead: git-duet (~/.git-authors), git-mob (~/.git-coauthors), git-pair
+(~/.pairs) or mailmap (.mailmap in this repository). The file defaults to
+the tool's usual location. Initials from those tools become aliases, so
+'gh pair add jd' works as before.
+
+By default the file is merged: people missing here are added and existing
+entries and settings are kept. With --replace, the file's contents replace
+the pairs, recent pairs, roster and settings here. 'gh pair undo' reverts
+the changes to pairs and branch pairs in one step; changes to recent
+pairs, the roster and settings can't be undone.
+
+Examples:
+  gh pair import pairs-backup.json
+  gh pair import --replace pairs-backup.json
+  gh pair import --from git-duet
+  gh pair import --from mailmap`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		if importFrom != "" {
+			return importAuthors(importFrom, args)
+		}
+		if len(args) == 0 {
+			return errors.New("specify a file to import, or --from with another tool's format")
+		}
+
+		data, err := readInput(args[0])
+		if err != nil {
+			return err
+		}
+
+		bundle, err := config.ParseBundle(args[0], data)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", args[0], err)
+		}
+
+		result, err := config.Import(bundle, importReplace)
+		if err != nil {
+			return fmt.Errorf("failed to import: %w", err)
+		}
+
+		if bundle.Settings != nil {
+			if err := importSettings(bundle.Settings, importReplace); err != nil {
+				return fmt.Errorf("failed to import settings: %w", err)
+			}
+		}
+
+		fmt.Printf("✓ Imported %d pairs, %d branch pair sets, %d recent pairs and %d roster entries\n",
+			result.Pairs, result.Branches, result.Recent, result.Roster)
+		return nil
+	},
+}
+
+// importAuthors imports another pairing tool's authors into the roster.
+func importAuthors(format string, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		var err error
+		if path, err = authors.DefaultPath(format); err != nil {
+			return err
+		}
+	}
+
+	data, err := readInput(path)
+	if err != nil {
+		return err
+	}
+
+	entries, err := authors.Parse(format, data)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	added, err := config.ImportRoster(entries, importReplace)
+	if err != nil {
+		return fmt.Errorf("failed to import: %w", err)
+	}
+
+	fmt.Printf("✓ Imported %d of %d authors from %s into the roster\n", added, len(entries), path)
+	for _, e := range entries {
+		login := "@" + e.Username
+		if e.Guessed {
+			login = "(" + e.Username + ")"
+		}
+		fmt.Printf("  %-6s %-21s %s <%s>\n", strings.Join(e.Aliases, ", "), login, e.Name, e.Email)
+	}
+	return nil
+}
+
+// readInput reads a file, or stdin if path is "-".
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%s not found", path)
+	}
+	return data, err
+}
+
+// importSettings applies imported settings. When merging, only settings
+// left at their defaults are changed.
+func importSettings(imported *config.BundleSettings, replace bool) error {
+	_, err := settings.Update(func(cfg *settings.Config) {
+		if imported.Theme != "" && (replace || cfg.Theme == "default") {
+			cfg.Theme = imported.Theme
+		}
+		if imported.RecentSize > 0 && (replace || cfg.RecentSize == 0) {
+			cfg.RecentSize = imported.RecentSize
+		}
+	})
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.Flags().BoolVar(&importReplace, "replace", false, "Replace existing configuration instead of merging")
+	importCmd.Flags().StringVar(&importFrom, "from", "", "Import authors from another tool: "+strings.Join(authors.Formats, ", "))
+}
@@ -30,7 +30,14 @@ If a commit-msg hook already exists, it will be backed up.`,
 		}
 
 		if hook.IsInstalled() {
-			fmt.Println("✓ Hook already installed")
+			if hook.IsCurrent() {
+				fmt.Println("✓ Hook already installed")
+				return nil
+			}
+			if err := hook.Install(); err != nil {
+				return fmt.Errorf("failed to update hook: %w", err)
+			}
+			fmt.Println("✓ Hook updated")
 			return nil
 		}
 
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/omgitsads/gh-pair/internal/config"
+	"github.com/omgitsads/gh-pair/internal/hook"
 )
 
 var listCmd = &cobra.Command{
@@ -23,16 +24,29 @@ var listCmd = &cobra.Command{
 			return fmt.Errorf("failed to load pairs: %w", err)
 		}
 
+		scope := config.ScopeDescription()
+		if branch := config.ActiveBranch(); branch != "" {
+			scope += ", branch " + branch
+		}
+
+		if hook.IsInstalled() && !hook.IsCurrent() {
+			defer fmt.Println("\nRun 'gh pair init' to update the commit hook")
+		}
+
 		if len(pairs.Pairs) == 0 {
-			fmt.Println("No pairs configured")
+			fmt.Printf("No pairs configured (%s)\n", scope)
 			fmt.Println("Use 'gh pair add @username' to add pairs")
 			return nil
 		}
 
-		fmt.Println("Current pairs:")
+		fmt.Printf("Current pairs (%s):\n", scope)
 		for _, p := range pairs.Pairs {
 			fmt.Printf("  @%-20s %s <%s>\n", p.Username, p.Name, p.Email)
 		}
+
+		if driver, err := config.LoadDriver(); err == nil && driver != nil {
+			fmt.Printf("\nDriver: %s <%s>\n", driver.Driver.Name, driver.Driver.Email)
+		}
 		return nil
 	},
 }
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+	"github.com/omgitsads/gh-pair/internal/git"
+	"github.com/omgitsads/gh-pair/internal/github"
+	"github.com/omgitsads/gh-pair/internal/tui"
+)
+
+var mobInterval time.Duration
+
+var mobCmd = &cobra.Command{
+	Use:   "mob",
+	Short: "Run a mob programming session with a rotating driver",
+	Long: `Run a mob programming session where the driver rotates on a timer.
+
+The driver becomes the author of new commits (via the repository's
+user.name and user.email) and everyone else is added as a co-author.
+Without a subcommand, shows the countdown for the running session.
+
+Examples:
+  gh pair mob start @octocat @hubot --interval 10m
+  gh pair mob next
+  gh pair mob stop`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		session, err := config.LoadMob()
+		if err != nil {
+			return fmt.Errorf("failed to load mob session: %w", err)
+		}
+		if session == nil {
+			return config.ErrNoMob
+		}
+
+		return tui.RunMob(getThemeName(), session)
+	},
+}
+
+var mobStartCmd = &cobra.Command{
+	Use:   "start <@username>...",
+	Short: "Start a mob session",
+	Long: `Start a mob session with you and the given GitHub users.
+You drive first, then the driver rotates through the participants
+in the order given, every --interval.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		owner, err := currentIdentity()
+		if err != nil {
+			return err
+		}
+
+		participants := []config.Pair{owner}
+		for _, username := range args {
+			pair, err := github.LookupUser(username)
+			if err != nil {
+				return err
+			}
+			participants = append(participants, *pair)
+		}
+
+		session, err := config.StartMob(participants, mobInterval)
+		if err != nil {
+			return err
+		}
+
+		return tui.RunMob(getThemeName(), session)
+	},
+}
+
+var mobNextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Hand over to the next driver now",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		session, err := config.RotateMob()
+		if err != nil {
+			return err
+		}
+
+		driver := session.CurrentDriver()
+		fmt.Printf("✓ %s (@%s) is now driving\n", driver.Name, driver.Username)
+		return nil
+	},
+}
+
+var mobStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the running mob session",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		session, err := config.LoadMob()
+		if err != nil {
+			return fmt.Errorf("failed to load mob session: %w", err)
+		}
+		if session == nil {
+			fmt.Println("No mob session running")
+			return nil
+		}
+
+		driver := session.CurrentDriver()
+		remaining := time.Until(session.NextRotation()).Round(time.Second)
+		if remaining < 0 {
+			remaining = 0
+		}
+
+		fmt.Printf("Driver: %s <%s>\n", driver.Name, driver.Email)
+		fmt.Printf("Next:   @%s in %s\n", session.NextDriver().Username, remaining)
+		fmt.Println("Co-authors:")
+		for _, p := range session.Navigators() {
+			fmt.Printf("  @%-20s %s <%s>\n", p.Username, p.Name, p.Email)
+		}
+		return nil
+	},
+}
+
+var mobStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "End the mob session",
+	Long:  `End the mob session, restoring your commit identity and previous pairs.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		if err := config.StopMob(); err != nil {
+			return err
+		}
+
+		fmt.Println("✓ Mob session ended")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mobCmd)
+	mobCmd.AddCommand(mobStartCmd, mobNextCmd, mobStatusCmd, mobStopCmd)
+	mobStartCmd.Flags().DurationVar(&mobInterval, "interval", 10*time.Minute, "How often the driver rotates")
+}
+
+// currentIdentity returns the git identity of whoever is using this machine,
+// as a pair so it can take part in sessions.
+func currentIdentity() (config.Pair, error) {
+	name := git.ConfigGet("user.name")
+	email := git.ConfigGet("user.email")
+	if name == "" || email == "" {
+		return config.Pair{}, fmt.Errorf("git user.name and user.email must be set")
+	}
+
+	username, err := github.GetAuthenticatedUser()
+	if err != nil || username == "" {
+		username = strings.ToLower(strings.Fields(name)[0])
+	}
+
+	return config.Pair{Username: username, Name: name, Email: email}, nil
+}
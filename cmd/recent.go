@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+	"github.com/omgitsads/gh-pair/internal/settings"
+)
+
+var recentCmd = &cobra.Command{
+	Use:   "recent",
+	Short: "Manage recently used pairs",
+	Long: `Show and manage the recently used pairs offered in the TUI.
+
+Pinned pairs stay at the top of the list and are never dropped. The
+number of other recent pairs kept can be changed with 'recent size'.
+
+Examples:
+  gh pair recent
+  gh pair recent pin @octocat
+  gh pair recent remove @octocat
+  gh pair recent size 20`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return recentListCmd.RunE(cmd, args)
+	},
+}
+
+var recentListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List recently used pairs",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		recent, err := config.LoadRecent()
+		if err != nil {
+			return fmt.Errorf("failed to load recent pairs: %w", err)
+		}
+
+		if len(recent.Recent) == 0 {
+			fmt.Println("No recent pairs")
+			return nil
+		}
+
+		fmt.Println("Recent pairs:")
+		for _, p := range recent.Recent {
+			pin := " "
+			if recent.IsPinned(p.Username) {
+				pin = "📌"
+			}
+			fmt.Printf("%s @%-20s %s <%s>\n", pin, p.Username, p.Name, p.Email)
+		}
+		return nil
+	},
+}
+
+var recentRemoveCmd = &cobra.Command{
+	Use:     "remove <@username>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a user from the recent list",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		username := strings.TrimPrefix(args[0], "@")
+		removed, err := config.RemoveRecent(username)
+		if err != nil {
+			return fmt.Errorf("failed to remove @%s: %w", username, err)
+		}
+
+		fmt.Printf("✓ Removed from recent: %s <%s>\n", removed.Name, removed.Email)
+		return nil
+	},
+}
+
+var recentClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Clear the recent list, keeping pinned pairs",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		if err := config.ClearRecent(); err != nil {
+			return fmt.Errorf("failed to clear recent pairs: %w", err)
+		}
+
+		fmt.Println("✓ Recent pairs cleared (pinned pairs kept)")
+		return nil
+	},
+}
+
+var recentPinCmd = &cobra.Command{
+	Use:   "pin <@username>",
+	Short: "Pin a user to the top of the recent list",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		pair, err := resolvePair(args[0])
+		if err != nil {
+			return err
+		}
+
+		if err := config.PinRecent(*pair); err != nil {
+			return fmt.Errorf("failed to pin @%s: %w", pair.Username, err)
+		}
+
+		fmt.Printf("✓ Pinned: %s <%s>\n", pair.Name, pair.Email)
+		return nil
+	},
+}
+
+var recentUnpinCmd = &cobra.Command{
+	Use:   "unpin <@username>",
+	Short: "Unpin a user, leaving them in the recent list",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		username := strings.TrimPrefix(args[0], "@")
+		if err := config.UnpinRecent(username); err != nil {
+			return fmt.Errorf("failed to unpin @%s: %w", username, err)
+		}
+
+		fmt.Printf("✓ Unpinned: @%s\n", username)
+		return nil
+	},
+}
+
+var recentSizeCmd = &cobra.Command{
+	Use:   "size [<n>]",
+	Short: "Show or set how many recent pairs are kept",
+	Long: `Show or set how many recent pairs are kept, not counting pinned pairs.
+The setting is saved in the global config and applies to all repositories.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			fmt.Printf("Recent pairs kept: %d\n", config.RecentSize())
+			return nil
+		}
+
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid size %q: must be a positive number", args[0])
+		}
+
+		_, err = settings.Update(func(cfg *settings.Config) {
+			cfg.RecentSize = n
+		})
+		if err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		fmt.Printf("✓ Keeping %d recent pairs\n", n)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(recentCmd)
+	recentCmd.AddCommand(recentListCmd)
+	recentCmd.AddCommand(recentRemoveCmd)
+	recentCmd.AddCommand(recentClearCmd)
+	recentCmd.AddCommand(recentPinCmd)
+	recentCmd.AddCommand(recentUnpinCmd)
+	recentCmd.AddCommand(recentSizeCmd)
+}
@@ -6,13 +6,16 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/omgitsads/gh-pair/internal/config"
 	"github.com/omgitsads/gh-pair/internal/git"
-	"github.com/omgitsads/gh-pair/internal/theme"
+	"github.com/omgitsads/gh-pair/internal/hook"
+	"github.com/omgitsads/gh-pair/internal/settings"
 	"github.com/omgitsads/gh-pair/internal/tui"
 )
 
 var themeName string
 var themeFlag bool // tracks if --theme was explicitly set
+var accessibleFlag bool
 
 var rootCmd = &cobra.Command{
 	Use:   "gh-pair",
@@ -22,13 +25,22 @@ co-authors for pair programming. It configures a git hook to
 automatically add Co-Authored-By trailers to your commits.
 
 Run without arguments to launch the interactive TUI, or use
-subcommands for quick operations.`,
+subcommands for quick operations. With --accessible, or when TERM is
+"dumb" or NO_COLOR is set, a plain prompt with numbered choices is used
+instead of the TUI.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return applySettings()
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Check if we're in a git repo
 		if !git.IsInsideWorkTree() {
 			return fmt.Errorf("not a git repository")
 		}
 
+		if accessibleMode() {
+			return tui.RunAccessible(os.Stdin, os.Stdout)
+		}
+
 		// Launch the TUI with theme
 		return tui.RunWithTheme(getThemeName())
 	},
@@ -41,6 +53,7 @@ func Execute() error {
 
 func init() {
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.Flags().BoolVar(&accessibleFlag, "accessible", false, "Use plain numbered prompts instead of the TUI, for screen readers")
 	rootCmd.PersistentFlags().StringVar(&themeName, "theme", "", "Color theme (default, dracula, nord, solarized-dark, solarized-light, catppuccin)")
 }
 
@@ -49,7 +62,58 @@ func getThemeName() string {
 	if themeName != "" {
 		return themeName
 	}
-	return theme.GetConfiguredTheme()
+	return settings.Load().Theme
+}
+
+// accessibleMode reports whether to use the accessible prompts instead of
+// the TUI: when asked to, or when the terminal can't show the TUI as meant.
+func accessibleMode() bool {
+	if accessibleFlag || settings.Load().Accessible {
+		return true
+	}
+	return os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb"
+}
+
+// applySettings configures packages from the global config file.
+func applySettings() error {
+	cfg := settings.Load()
+
+	if git.IsInsideWorkTree() {
+		if kept, err := config.KeepLegacyStorage(cfg.Storage, cfg.StorageScope); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to keep the storage chosen by an older version: %v\n", err)
+		} else if kept {
+			fmt.Fprintf(os.Stderr, "Found pairs stored in %s by an older version; keeping them there for this repository\n", cfg.Storage)
+		}
+	}
+
+	// A bad setting mustn't stop 'gh pair storage set' from fixing it
+	storage, err := config.RepoStorage()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: invalid storage setting: %v; using %s. Fix it with 'gh pair storage set'\n", err, config.StorageJSON)
+		storage = config.JSONStorage{}
+	}
+	config.UseStorage(storage)
+	config.SetRecentSize(cfg.RecentSize)
+	hook.SetBranchReminder(cfg.Reminder())
+
+	if git.IsInsideWorkTree() {
+		if kept, err := config.KeepLegacyScope(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to check for per-worktree pairs: %v\n", err)
+		} else if kept {
+			fmt.Fprintf(os.Stderr, "Found pairs kept per worktree by an older version; keeping pairs per worktree (run 'gh pair scope set %s' to share them)\n", git.ScopeShared)
+		}
+
+		// A corrupt journal or busy lock mustn't block 'gh pair undo'
+		expired, err := config.ExpirePairs(cfg.Expiry())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to expire pairs: %v\n", err)
+		}
+		if expired {
+			fmt.Fprintf(os.Stderr, "Pairs cleared after %s without changes (gh pair undo restores them)\n", cfg.SessionExpiry)
+		}
+	}
+
+	return nil
 }
 
 // checkGitRepo is a helper that verifies we're in a git repository.
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+	"github.com/omgitsads/gh-pair/internal/git"
+	"github.com/omgitsads/gh-pair/internal/hook"
+)
+
+var scopeCmd = &cobra.Command{
+	Use:   "scope",
+	Short: "Show whether pairs are shared between worktrees",
+	Long: `Show or change whether pairs are shared between worktrees.
+
+By default all worktrees of a repository share one set of pairs, stored
+in the common git directory. With the worktree scope, each worktree
+created with 'git worktree add' keeps its own pairs.
+
+Examples:
+  gh pair scope
+  gh pair scope set worktree
+  gh pair scope set shared`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		fmt.Printf("Scope: %s (pairs apply to %s)\n", git.PairScope(), config.ScopeDescription())
+		return nil
+	},
+}
+
+var scopeSetCmd = &cobra.Command{
+	Use:       "set <shared|worktree>",
+	Short:     "Set whether pairs are shared between worktrees",
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{git.ScopeShared, git.ScopeWorktree},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		if err := config.SwitchScope(args[0]); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Pairs now apply to %s\n", config.ScopeDescription())
+		if hook.IsInstalled() && !hook.IsCurrent() {
+			fmt.Println("  Run 'gh pair init' to update the commit hook")
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(scopeCmd)
+	scopeCmd.AddCommand(scopeSetCmd)
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/omgitsads/gh-pair/internal/history"
+)
+
+var statsSince string
+var statsUntil string
+var statsAuthor string
+var statsAll bool
+var statsJSON bool
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show who has paired with whom",
+	Long: `Summarise pairing history from the Co-Authored-By trailers in git log.
+
+Shows how often each pair of people committed together, and how many
+commits and distinct partners each person has. Use this to spot people
+who always pair with the same partners.
+
+Examples:
+  gh pair stats
+  gh pair stats --since "1 month ago"
+  gh pair stats --author @octocat --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		opts := history.Options{Since: statsSince, Until: statsUntil}
+		if statsAll {
+			opts.Revs = []string{"--all"}
+		}
+
+		commits, err := history.Load(opts)
+		if err != nil {
+			return fmt.Errorf("failed to read history: %w", err)
+		}
+
+		report := history.Summarize(commits, statsAuthor)
+
+		if statsJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(report)
+		}
+
+		if report.Commits == 0 {
+			fmt.Println("No co-authored commits found")
+			return nil
+		}
+
+		fmt.Printf("Co-authored commits: %d\n", report.Commits)
+		fmt.Println()
+		fmt.Println("Pairings:")
+		for _, p := range report.Pairings {
+			fmt.Printf("  %-24s %-24s %5d   last %s\n",
+				p.People[0].Name, p.People[1].Name, p.Commits, formatDate(p.Last))
+		}
+
+		fmt.Println()
+		fmt.Println("Authors:")
+		fmt.Printf("  %-24s %7s %9s\n", "", "commits", "partners")
+		for _, a := range report.Authors {
+			fmt.Printf("  %-24s %7d %9d   last %s\n",
+				a.Name, a.Commits, a.Partners, formatDate(a.Last))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.Flags().StringVar(&statsSince, "since", "", "Only count commits after this date (e.g. 2024-01-01, \"2 weeks ago\")")
+	statsCmd.Flags().StringVar(&statsUntil, "until", "", "Only count commits before this date")
+	statsCmd.Flags().StringVar(&statsAuthor, "author", "", "Only count commits involving this person (email, name, or @username)")
+	statsCmd.Flags().BoolVar(&statsAll, "all", false, "Include commits from all branches")
+	statsCmd.Flags().BoolVar(&statsJSON, "json", false, "Output as JSON")
+}
+
+// formatDate formats a commit date for table output.
+func formatDate(t time.Time) string {
+	return t.Format("2006-01-02")
+}
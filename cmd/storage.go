@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+	"github.com/omgitsads/gh-pair/internal/hook"
+)
+
+var storageScope string
+
+var storageCmd = &cobra.Command{
+	Use:   "storage",
+	Short: "Show where pairs are stored",
+	Long: `Show or change where pairs are stored.
+
+Pairs are kept in .git/gh-pair/pairs.json by default. The gitconfig
+backend stores them in git config instead, as "Name <email>" values of
+the multi-valued pair.coauthor key, so IDEs and other tools can read them.
+The backend is chosen separately for each repository.
+
+Examples:
+  gh pair storage
+  gh pair storage set gitconfig
+  gh pair storage set gitconfig --scope global
+  gh pair storage set json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Printf("Pairs are stored in: %s\n", config.CurrentStorage().Name())
+	},
+}
+
+var storageSetCmd = &cobra.Command{
+	Use:   "set <json|gitconfig>",
+	Short: "Set where pairs are stored",
+	Long: `Set where pairs are stored in the current repository. Its pairs are
+moved to the new backend; other repositories keep their own setting.`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{config.StorageJSON, config.StorageGitConfig},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		storage, err := config.NewStorage(args[0], storageScope)
+		if err != nil {
+			return err
+		}
+
+		if err := config.SwitchStorage(storage); err != nil {
+			return fmt.Errorf("failed to move pairs: %w", err)
+		}
+
+		fmt.Printf("✓ Pairs are now stored in: %s\n", storage.Name())
+		if hook.IsInstalled() && !hook.IsCurrent() {
+			fmt.Println("  Run 'gh pair init' to update the commit hook")
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(storageCmd)
+	storageCmd.AddCommand(storageSetCmd)
+	storageSetCmd.Flags().StringVar(&storageScope, "scope", "", "Git config scope for the gitconfig backend (local, worktree, global)")
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/omgitsads/gh-pair/internal/github"
+	"github.com/omgitsads/gh-pair/internal/history"
+)
+
+var suggestTeam string
+var suggestLimit int
+var suggestJSON bool
+
+var suggestCmd = &cobra.Command{
+	Use:   "suggest",
+	Short: "Suggest who to pair with next",
+	Long: `Suggest teammates to pair with, least recently paired first.
+
+Teammates are the members of your GitHub teams, or of a single team
+with --team. Pairing history comes from the Co-Authored-By trailers on
+all branches of the current repository.
+
+Examples:
+  gh pair suggest
+  gh pair suggest --team my-org/my-team --limit 3`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		roster, err := github.GetTeammates(suggestTeam)
+		if err != nil {
+			return err
+		}
+
+		currentUser, _ := github.GetAuthenticatedUser()
+		suggestions, err := history.SuggestFromRoster(roster, currentUser)
+		if err != nil {
+			return fmt.Errorf("failed to read history: %w", err)
+		}
+
+		if suggestLimit > 0 && len(suggestions) > suggestLimit {
+			suggestions = suggestions[:suggestLimit]
+		}
+
+		if suggestJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(suggestions)
+		}
+
+		if len(suggestions) == 0 {
+			fmt.Println("No teammates found")
+			return nil
+		}
+
+		now := time.Now()
+		fmt.Println("Suggested pairs:")
+		for _, s := range suggestions {
+			fmt.Printf("  @%-20s %s\n", s.Pair.Username, history.Ago(s.LastPaired, now))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(suggestCmd)
+	suggestCmd.Flags().StringVar(&suggestTeam, "team", "", "Only suggest members of this team (org/slug)")
+	suggestCmd.Flags().IntVarP(&suggestLimit, "limit", "n", 5, "Maximum number of suggestions (0 for all)")
+	suggestCmd.Flags().BoolVar(&suggestJSON, "json", false, "Output as JSON")
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
+	"github.com/omgitsads/gh-pair/internal/settings"
 	"github.com/omgitsads/gh-pair/internal/theme"
 )
 
@@ -58,7 +59,10 @@ var themeSetCmd = &cobra.Command{
 			}
 		}
 
-		if err := theme.SetConfiguredTheme(name); err != nil {
+		_, err := settings.Update(func(cfg *settings.Config) {
+			cfg.Theme = name
+		})
+		if err != nil {
 			return fmt.Errorf("failed to save theme: %w", err)
 		}
 
@@ -76,7 +80,7 @@ func init() {
 }
 
 func listThemes() {
-	currentTheme := theme.GetConfiguredTheme()
+	currentTheme := settings.Load().Theme
 	
 	fmt.Println("Available themes:")
 	fmt.Println()
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+)
+
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Undo the last change to your pairs",
+	Long: `Restore the pairs as they were before the last add, remove or clear.
+
+Up to 50 changes are remembered per repository. Use 'gh pair redo' to
+reapply an undone change.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		entry, err := config.Undo()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Undid: %s\n", entry.Action)
+		return nil
+	},
+}
+
+var redoCmd = &cobra.Command{
+	Use:   "redo",
+	Short: "Redo the last undone change to your pairs",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkGitRepo(); err != nil {
+			return err
+		}
+
+		entry, err := config.Redo()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Redid: %s\n", entry.Action)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(undoCmd)
+	rootCmd.AddCommand(redoCmd)
+}
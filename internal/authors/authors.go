@@ -0,0 +1,91 @@
+// Package authors reads the author files of other pairing tools and
+// .mailmap, so their authors can be imported into the gh-pair roster.
+package authors
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+	"github.com/omgitsads/gh-pair/internal/git"
+)
+
+// Supported formats.
+const (
+	GitDuet = "git-duet"
+	GitMob  = "git-mob"
+	GitPair = "git-pair"
+	Mailmap = "mailmap"
+)
+
+// Formats lists the supported formats.
+var Formats = []string{GitDuet, GitMob, GitPair, Mailmap}
+
+// Parse reads authors from the contents of a file in the given format.
+func Parse(format string, data []byte) ([]config.RosterEntry, error) {
+	switch format {
+	case GitDuet, GitPair:
+		return parseYAMLAuthors(data)
+	case GitMob:
+		return parseCoAuthors(data)
+	case Mailmap:
+		return parseMailmap(data), nil
+	}
+	return nil, unknownFormat(format)
+}
+
+// DefaultPath returns where a format's file is found when no path is given:
+// the tool's file in the home directory, or .mailmap at the repository root.
+func DefaultPath(format string) (string, error) {
+	if format == Mailmap {
+		root, err := git.RepoRoot()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(root, ".mailmap"), nil
+	}
+
+	var name, env string
+	switch format {
+	case GitDuet:
+		name, env = ".git-authors", "GIT_DUET_AUTHORS_FILE"
+	case GitMob:
+		name, env = ".git-coauthors", "GITMOB_COAUTHORS_PATH"
+	case GitPair:
+		name = ".pairs"
+	default:
+		return "", unknownFormat(format)
+	}
+
+	// The tools let their file be moved with an environment variable
+	if env != "" && os.Getenv(env) != "" {
+		return os.Getenv(env), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, name), nil
+}
+
+func unknownFormat(format string) error {
+	return fmt.Errorf("unknown format %q (expected %s)", format, strings.Join(Formats, ", "))
+}
+
+// entry builds a roster entry, guessing the username from the email. Only
+// GitHub noreply addresses carry the real login; other guesses are marked
+// so they are never taken for someone's GitHub account.
+func entry(name, email string, aliases ...string) config.RosterEntry {
+	return config.RosterEntry{
+		Pair: config.Pair{
+			Username: config.UsernameFromEmail(email),
+			Name:     name,
+			Email:    email,
+		},
+		Aliases: aliases,
+		Guessed: !strings.HasSuffix(strings.ToLower(email), "@users.noreply.github.com"),
+	}
+}
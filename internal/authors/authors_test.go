@@ -0,0 +1,114 @@
+package authors
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+)
+
+func TestParse(t *testing.T) {
+	jane := config.RosterEntry{
+		Pair:    config.Pair{Username: "jane", Name: "Jane Doe", Email: "jane@example.com"},
+		Aliases: []string{"jd"},
+		Guessed: true,
+	}
+	octo := config.RosterEntry{
+		Pair:    config.Pair{Username: "octocat", Name: "Mona Lisa", Email: "1+octocat@users.noreply.github.com"},
+		Aliases: []string{"ml"},
+	}
+
+	tests := []struct {
+		name    string
+		format  string
+		data    string
+		want    []config.RosterEntry
+		wantErr bool
+	}{
+		{
+			name:   "git-duet with email addresses",
+			format: GitDuet,
+			data: `authors:
+  jd: Jane Doe; jane
+  ml: Mona Lisa; octocat
+email_addresses:
+  jd: jane@example.com
+  ml: 1+octocat@users.noreply.github.com
+`,
+			want: []config.RosterEntry{jane, octo},
+		},
+		{
+			name:   "git-duet emails from the domain",
+			format: GitDuet,
+			data: `authors:
+  jd: Jane Doe; jane
+email:
+  domain: example.com
+`,
+			want: []config.RosterEntry{jane},
+		},
+		{
+			name:   "git-pair uses the first name without a username",
+			format: GitPair,
+			data: `pairs:
+  jd: Jane Doe
+email:
+  domain: example.com
+`,
+			want: []config.RosterEntry{jane},
+		},
+		{
+			name:    "git-pair without authors",
+			format:  GitPair,
+			data:    "email:\n  domain: example.com\n",
+			wantErr: true,
+		},
+		{
+			name:   "git-mob",
+			format: GitMob,
+			data: `{"coauthors": {
+  "ml": {"name": "Mona Lisa", "email": "1+octocat@users.noreply.github.com"},
+  "jd": {"name": "Jane Doe", "email": "jane@example.com"},
+  "xx": {"name": "No Email", "email": ""}
+}}`,
+			want: []config.RosterEntry{jane, octo},
+		},
+		{
+			name:    "git-mob invalid JSON",
+			format:  GitMob,
+			data:    `{"coauthors": `,
+			wantErr: true,
+		},
+		{
+			name:   "mailmap",
+			format: Mailmap,
+			data: `# comment
+Jane Doe <jane@example.com> <jane@old.example.com>
+Jane Doe <JANE@example.com> Jane <jane@laptop>
+<only@email.com> <other@email.com>
+Mona Lisa <1+octocat@users.noreply.github.com> # trailing comment
+`,
+			want: []config.RosterEntry{
+				{Pair: jane.Pair, Guessed: true},
+				{Pair: octo.Pair},
+			},
+		},
+		{
+			name:    "unknown format",
+			format:  "git-solo",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.format, []byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
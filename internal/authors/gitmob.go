@@ -0,0 +1,47 @@
+package authors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+)
+
+// parseCoAuthors reads git-mob's .git-coauthors file:
+//
+//	{"coauthors": {"jd": {"name": "Jane Doe", "email": "jane@example.com"}}}
+//
+// Initials become aliases.
+func parseCoAuthors(data []byte) ([]config.RosterEntry, error) {
+	var file struct {
+		CoAuthors map[string]struct {
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		} `json:"coauthors"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid .git-coauthors file: %w", err)
+	}
+	if len(file.CoAuthors) == 0 {
+		return nil, errors.New("no co-authors found")
+	}
+
+	initials := make([]string, 0, len(file.CoAuthors))
+	for k := range file.CoAuthors {
+		initials = append(initials, k)
+	}
+	sort.Strings(initials)
+
+	entries := make([]config.RosterEntry, 0, len(initials))
+	for _, key := range initials {
+		author := file.CoAuthors[key]
+		if author.Name == "" || author.Email == "" {
+			continue
+		}
+		entries = append(entries, entry(author.Name, author.Email, key))
+	}
+
+	return entries, nil
+}
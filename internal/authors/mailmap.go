@@ -0,0 +1,43 @@
+package authors
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+)
+
+// parseMailmap reads the proper name and email of each .mailmap line:
+//
+//	Proper Name <proper@email> [Commit Name] [<commit@email>]
+//
+// Lines that only map emails carry no name and are skipped. Each person is
+// returned once, with the first email listed for them.
+func parseMailmap(data []byte) []config.RosterEntry {
+	var entries []config.RosterEntry
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+
+		start := strings.Index(line, "<")
+		end := strings.Index(line, ">")
+		if start <= 0 || end <= start {
+			continue
+		}
+
+		name := strings.TrimSpace(line[:start])
+		email := strings.TrimSpace(line[start+1 : end])
+		key := strings.ToLower(email)
+		if name == "" || email == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		entries = append(entries, entry(name, email))
+	}
+
+	return entries
+}
@@ -0,0 +1,114 @@
+package authors
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+)
+
+// parseYAMLAuthors reads the .git-authors file of git-duet and the .pairs
+// file of git-pair, which share a layout:
+//
+//	authors:            # "pairs:" in git-pair and older git-duet files
+//	  jd: Jane Doe; jane
+//	email:
+//	  domain: example.com
+//	email_addresses:
+//	  jd: jane.doe@example.com
+//
+// Emails come from email_addresses, or from the username (or the first
+// name) at the domain. Initials become aliases.
+func parseYAMLAuthors(data []byte) ([]config.RosterEntry, error) {
+	doc := parseSimpleYAML(data)
+
+	people := doc["authors"]
+	if people == nil {
+		people = doc["pairs"]
+	}
+	if len(people) == 0 {
+		return nil, errors.New("no authors found")
+	}
+	domain := doc["email"]["domain"]
+	addresses := doc["email_addresses"]
+
+	initials := make([]string, 0, len(people))
+	for k := range people {
+		initials = append(initials, k)
+	}
+	sort.Strings(initials)
+
+	entries := make([]config.RosterEntry, 0, len(initials))
+	for _, key := range initials {
+		name, username, _ := strings.Cut(people[key], ";")
+		name = strings.TrimSpace(name)
+		username = strings.TrimSpace(username)
+
+		email := addresses[key]
+		if email == "" && domain != "" {
+			local := username
+			if local == "" {
+				local, _, _ = strings.Cut(strings.ToLower(name), " ")
+			}
+			email = local + "@" + domain
+		}
+		if name == "" || email == "" {
+			continue
+		}
+
+		entries = append(entries, entry(name, email, key))
+	}
+
+	return entries, nil
+}
+
+// parseSimpleYAML reads the two-level mappings used by author files: top
+// level keys each holding indented "key: value" lines. Anything else is
+// ignored.
+func parseSimpleYAML(data []byte) map[string]map[string]string {
+	doc := make(map[string]map[string]string)
+	var section string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			continue
+		}
+		key = unquote(strings.TrimSpace(key))
+		value = unquote(strings.TrimSpace(value))
+
+		if line[0] != ' ' && line[0] != '\t' {
+			section = key
+			if doc[section] == nil {
+				doc[section] = make(map[string]string)
+			}
+			continue
+		}
+		if section != "" {
+			doc[section][key] = value
+		}
+	}
+
+	return doc
+}
+
+// unquote removes YAML quotes around a scalar.
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
@@ -1,17 +1,17 @@
 package config
 
 import (
-	"encoding/json"
-	"os"
-	"path/filepath"
+	"errors"
+	"strings"
 
 	"github.com/omgitsads/gh-pair/internal/git"
 )
 
+var ErrDetachedHead = errors.New("not on a branch; branch pairs need a checked out branch")
+
 const (
 	PairsFileName  = "pairs.json"
 	RecentFileName = "recent.json"
-	MaxRecentPairs = 10
 )
 
 // Pair represents a co-author for commits.
@@ -23,170 +23,241 @@ type Pair struct {
 
 // CoAuthorLine returns the Co-Authored-By trailer line for this pair.
 func (p Pair) CoAuthorLine() string {
-	return "Co-Authored-By: " + p.Name + " <" + p.Email + ">"
+	return coAuthorKey + ": " + p.Name + " <" + p.Email + ">"
+}
+
+// coAuthorKey is the trailer that credits a co-author.
+const coAuthorKey = "Co-Authored-By"
+
+// ParseCoAuthorLine reads the name and email from a Co-Authored-By trailer,
+// as written by CoAuthorLine. The key is matched case-insensitively.
+func ParseCoAuthorLine(line string) (Pair, bool) {
+	line = strings.TrimSpace(line)
+	key, value, ok := strings.Cut(line, ":")
+	if !ok || !strings.EqualFold(key, coAuthorKey) {
+		return Pair{}, false
+	}
+
+	start := strings.LastIndex(value, "<")
+	end := strings.LastIndex(value, ">")
+	if start == -1 || end <= start {
+		return Pair{}, false
+	}
+
+	return Pair{
+		Name:  strings.TrimSpace(value[:start]),
+		Email: strings.TrimSpace(value[start+1 : end]),
+	}, true
 }
 
 // PairsConfig holds the current active pairs.
 type PairsConfig struct {
+	Schema
 	Pairs []Pair `json:"pairs"`
 }
 
-// RecentConfig holds recently used pairs for quick access.
+// RecentConfig holds recently used pairs for quick access. Pinned users
+// are listed first and never fall off the list.
 type RecentConfig struct {
-	Recent []Pair `json:"recent"`
+	Schema
+	Recent []Pair   `json:"recent"`
+	Pinned []string `json:"pinned,omitempty"`
 }
 
-// LoadPairs loads the current pairs from the config file.
+// LoadPairs loads the pairs that apply on the current branch: its own
+// pairs if it has any, otherwise the default pairs.
 func LoadPairs() (*PairsConfig, error) {
-	configDir, err := git.ConfigDir()
-	if err != nil {
-		return nil, err
+	return LoadBranchPairs(ActiveBranch())
+}
+
+// SavePairs saves the pairs that apply on the current branch.
+func SavePairs(config *PairsConfig) error {
+	return SaveBranchPairs(ActiveBranch(), config)
+}
+
+// ActiveBranch returns the current branch if it has its own pairs, or an
+// empty string if the default pairs apply.
+func ActiveBranch() string {
+	branch := git.CurrentBranch()
+	if branch == "" {
+		return ""
 	}
+	if _, found, err := storage.Load(branch); err != nil || !found {
+		return ""
+	}
+	return branch
+}
 
-	path := filepath.Join(configDir, PairsFileName)
-	data, err := os.ReadFile(path)
+// LoadBranchPairs loads a branch's own pairs, or the default pairs if
+// branch is empty. A branch without its own pairs has none.
+func LoadBranchPairs(branch string) (*PairsConfig, error) {
+	config, _, err := storage.Load(branch)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return &PairsConfig{Pairs: []Pair{}}, nil
-		}
 		return nil, err
 	}
 
-	var config PairsConfig
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, err
+	if config.Pairs == nil {
+		config.Pairs = []Pair{}
 	}
 
-	return &config, nil
+	return config, nil
 }
 
-// SavePairs saves the pairs configuration to the config file.
-func SavePairs(config *PairsConfig) error {
-	configDir, err := git.EnsureConfigDir()
-	if err != nil {
+// SaveBranchPairs saves a branch's own pairs, or the default pairs if
+// branch is empty.
+func SaveBranchPairs(branch string, config *PairsConfig) error {
+	if err := storage.Save(branch, config); err != nil {
 		return err
 	}
+	return touch()
+}
 
-	data, err := json.MarshalIndent(config, "", "  ")
-	if err != nil {
+// deleteBranchPairs removes a branch's own pairs.
+func deleteBranchPairs(branch string) error {
+	if err := storage.Delete(branch); err != nil {
 		return err
 	}
-
-	path := filepath.Join(configDir, PairsFileName)
-	return os.WriteFile(path, data, 0644)
+	return touch()
 }
 
-// AddPair adds a pair to the config if not already present.
-func AddPair(pair Pair) error {
-	config, err := LoadPairs()
-	if err != nil {
-		return err
-	}
+// AddBranchPair adds a pair to the current branch's own pairs, starting
+// them if the branch doesn't have any yet. It returns the branch name.
+func AddBranchPair(pair Pair) (string, error) {
+	branch, _, err := AddBranchPairs([]Pair{pair})
+	return branch, err
+}
 
-	// Check if already exists
-	for _, p := range config.Pairs {
-		if p.Username == pair.Username {
-			return nil // Already exists
-		}
+// AddBranchPairs adds pairs to the current branch's own pairs as one
+// change. It returns the branch name and how many pairs were added.
+func AddBranchPairs(pairs []Pair) (string, int, error) {
+	branch := git.CurrentBranch()
+	if branch == "" {
+		return "", 0, ErrDetachedHead
 	}
 
-	config.Pairs = append(config.Pairs, pair)
-
-	if err := SavePairs(config); err != nil {
+	var added int
+	err := withLock(func() error {
+		var err error
+		added, err = addPairs(branch, pairs, " on "+branch)
 		return err
-	}
-
-	// Also add to recent
-	return AddToRecent(pair)
+	})
+	return branch, added, err
 }
 
-// RemovePair removes a pair from the config by username.
-func RemovePair(username string) error {
-	config, err := LoadPairs()
-	if err != nil {
-		return err
+// ClearBranchPairs removes the current branch's own pairs, so the default
+// pairs apply to it again. It returns the branch name.
+func ClearBranchPairs() (string, error) {
+	branch := git.CurrentBranch()
+	if branch == "" {
+		return "", ErrDetachedHead
 	}
 
-	newPairs := make([]Pair, 0, len(config.Pairs))
-	for _, p := range config.Pairs {
-		if p.Username != username {
-			newPairs = append(newPairs, p)
+	return branch, withLock(func() error {
+		if err := record("clear "+branch, branch); err != nil {
+			return err
 		}
-	}
+		return deleteBranchPairs(branch)
+	})
+}
 
-	config.Pairs = newPairs
-	return SavePairs(config)
+// AddPair adds a pair to the config if not already present.
+func AddPair(pair Pair) error {
+	_, err := AddPairs([]Pair{pair})
+	return err
 }
 
-// ClearPairs removes all pairs from the config.
-func ClearPairs() error {
-	config := &PairsConfig{Pairs: []Pair{}}
-	return SavePairs(config)
+// AddPairs adds pairs to the config as one change, skipping those already
+// present, and returns how many were added.
+func AddPairs(pairs []Pair) (int, error) {
+	var added int
+	err := withLock(func() error {
+		var err error
+		added, err = addPairs(ActiveBranch(), pairs, "")
+		return err
+	})
+	return added, err
 }
 
-// LoadRecent loads the recent pairs from the config file.
-func LoadRecent() (*RecentConfig, error) {
-	configDir, err := git.ConfigDir()
+// addPairs adds pairs to a set, skipping those already present, and
+// journals the change with suffix appended to its description. Callers
+// must hold the lock.
+func addPairs(branch string, pairs []Pair, suffix string) (int, error) {
+	config, err := LoadBranchPairs(branch)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	path := filepath.Join(configDir, RecentFileName)
-	data, err := os.ReadFile(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return &RecentConfig{Recent: []Pair{}}, nil
+	// Skip pairs that already exist
+	var added []Pair
+	for _, pair := range pairs {
+		if !containsPair(config.Pairs, pair.Username) && !containsPair(added, pair.Username) {
+			added = append(added, pair)
 		}
-		return nil, err
+	}
+	if len(added) == 0 {
+		return 0, nil
 	}
 
-	var config RecentConfig
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, err
+	usernames := make([]string, len(added))
+	for i, p := range added {
+		usernames[i] = "@" + p.Username
+	}
+	if err := record("add "+strings.Join(usernames, ", ")+suffix, branch); err != nil {
+		return 0, err
 	}
 
-	return &config, nil
-}
+	config.Pairs = append(config.Pairs, added...)
 
-// SaveRecent saves the recent pairs configuration.
-func SaveRecent(config *RecentConfig) error {
-	configDir, err := git.EnsureConfigDir()
-	if err != nil {
-		return err
+	if err := SaveBranchPairs(branch, config); err != nil {
+		return 0, err
 	}
 
-	data, err := json.MarshalIndent(config, "", "  ")
-	if err != nil {
-		return err
+	// Also add to recent
+	for _, pair := range added {
+		if err := addToRecent(pair); err != nil {
+			return 0, err
+		}
 	}
-
-	path := filepath.Join(configDir, RecentFileName)
-	return os.WriteFile(path, data, 0644)
+	return len(added), nil
 }
 
-// AddToRecent adds a pair to the recent list (moves to front if exists).
-func AddToRecent(pair Pair) error {
-	config, err := LoadRecent()
-	if err != nil {
-		return err
-	}
+// RemovePair removes a pair from the config by username.
+func RemovePair(username string) error {
+	return withLock(func() error {
+		branch := ActiveBranch()
+		config, err := LoadBranchPairs(branch)
+		if err != nil {
+			return err
+		}
 
-	// Remove if already exists
-	newRecent := make([]Pair, 0, MaxRecentPairs)
-	for _, p := range config.Recent {
-		if p.Username != pair.Username {
-			newRecent = append(newRecent, p)
+		newPairs := make([]Pair, 0, len(config.Pairs))
+		for _, p := range config.Pairs {
+			if p.Username != username {
+				newPairs = append(newPairs, p)
+			}
+		}
+		if len(newPairs) == len(config.Pairs) {
+			return nil // Not a pair
 		}
-	}
 
-	// Add to front
-	newRecent = append([]Pair{pair}, newRecent...)
+		if err := record("remove @"+username, branch); err != nil {
+			return err
+		}
 
-	// Trim to max size
-	if len(newRecent) > MaxRecentPairs {
-		newRecent = newRecent[:MaxRecentPairs]
-	}
+		config.Pairs = newPairs
+		return SaveBranchPairs(branch, config)
+	})
+}
+
+// ClearPairs removes all pairs from the config.
+func ClearPairs() error {
+	return withLock(func() error {
+		branch := ActiveBranch()
+		if err := record("clear", branch); err != nil {
+			return err
+		}
 
-	config.Recent = newRecent
-	return SaveRecent(config)
+		config := &PairsConfig{Pairs: []Pair{}}
+		return SaveBranchPairs(branch, config)
+	})
 }
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/omgitsads/gh-pair/internal/git"
+)
+
+const DriverFileName = "driver.json"
+
+var (
+	ErrNoDriver     = errors.New("no driver set; nothing to reset")
+	ErrDriverActive = errors.New("a driver is set; reset it with 'gh pair drive --reset'")
+)
+
+// DriverSession records that someone other than the machine owner is
+// authoring commits. The owner is credited as a co-author while it lasts.
+type DriverSession struct {
+	Schema
+	Driver   Pair         `json:"driver"`
+	Owner    Pair         `json:"owner"`
+	Original git.Identity `json:"original"`         // identity to restore on reset
+	Branch   string       `json:"branch,omitempty"` // pair set the session changes, "" for the default
+}
+
+// LoadDriver loads the active driver session, or returns nil if there is none.
+func LoadDriver() (*DriverSession, error) {
+	var session DriverSession
+	found, err := loadJSON(DriverFileName, &session)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// SaveDriver saves the driver session.
+func SaveDriver(session *DriverSession) error {
+	return saveJSON(DriverFileName, session)
+}
+
+// Drive makes driver the author of new commits in this repository and adds
+// owner as a co-author in their place. If a driver is already set, the
+// original owner is kept and the previous driver goes back to co-authoring.
+func Drive(driver, owner Pair) (*DriverSession, error) {
+	var session *DriverSession
+	err := withLock(func() error {
+		var err error
+		session, err = drive(driver, owner)
+		return err
+	})
+	return session, err
+}
+
+func drive(driver, owner Pair) (*DriverSession, error) {
+	mob, err := LoadMob()
+	if err != nil {
+		return nil, err
+	}
+	if mob != nil {
+		return nil, ErrMobActive
+	}
+
+	session, err := LoadDriver()
+	if err != nil {
+		return nil, err
+	}
+
+	var previous *Pair
+	if session == nil {
+		session = &DriverSession{Owner: owner, Original: git.LocalIdentity(), Branch: ActiveBranch()}
+	} else {
+		previous = &session.Driver
+	}
+
+	pairs, err := LoadBranchPairs(session.Branch)
+	if err != nil {
+		return nil, err
+	}
+
+	coAuthors := make([]Pair, 0, len(pairs.Pairs)+2)
+	for _, p := range pairs.Pairs {
+		if !samePerson(p, driver) {
+			coAuthors = append(coAuthors, p)
+		}
+	}
+	if previous != nil && !samePerson(*previous, driver) && !samePerson(*previous, session.Owner) {
+		coAuthors = appendUnique(coAuthors, *previous)
+	}
+	if !samePerson(session.Owner, driver) {
+		coAuthors = appendUnique(coAuthors, session.Owner)
+	}
+
+	if err := recordSession("drive "+driver.Name, session.Branch); err != nil {
+		return nil, err
+	}
+
+	session.Driver = driver
+	if err := SaveDriver(session); err != nil {
+		return nil, err
+	}
+	if err := git.SetLocalIdentity(driver.Name, driver.Email); err != nil {
+		return nil, err
+	}
+	if err := SaveBranchPairs(session.Branch, &PairsConfig{Pairs: coAuthors}); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// ResetDriver hands authorship back to the machine owner, restoring their
+// identity and returning the driver to the co-author list.
+func ResetDriver() (*DriverSession, error) {
+	var session *DriverSession
+	err := withLock(func() error {
+		var err error
+		session, err = resetDriver()
+		return err
+	})
+	return session, err
+}
+
+func resetDriver() (*DriverSession, error) {
+	session, err := LoadDriver()
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrNoDriver
+	}
+
+	if err := recordSession("reset driver", session.Branch); err != nil {
+		return nil, err
+	}
+	if err := session.Original.Restore(); err != nil {
+		return nil, err
+	}
+
+	pairs, err := LoadBranchPairs(session.Branch)
+	if err != nil {
+		return nil, err
+	}
+
+	coAuthors := make([]Pair, 0, len(pairs.Pairs)+1)
+	for _, p := range pairs.Pairs {
+		if !samePerson(p, session.Owner) {
+			coAuthors = append(coAuthors, p)
+		}
+	}
+	if !samePerson(session.Driver, session.Owner) {
+		coAuthors = appendUnique(coAuthors, session.Driver)
+	}
+
+	if err := SaveBranchPairs(session.Branch, &PairsConfig{Pairs: coAuthors}); err != nil {
+		return nil, err
+	}
+
+	if err := removeFile(DriverFileName); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// OwnerEmail returns the email the machine owner commits with. While a
+// driver or mob session is active, user.email is the driver's, so the
+// owner's is read from the identity the session saved.
+func OwnerEmail() string {
+	if session, err := LoadDriver(); err == nil && session != nil {
+		return session.Original.CommitEmail()
+	}
+	if session, err := LoadMob(); err == nil && session != nil {
+		return session.Original.CommitEmail()
+	}
+	return git.ConfigGet("user.email")
+}
+
+// samePerson reports whether two pairs refer to the same person.
+func samePerson(a, b Pair) bool {
+	return (a.Username != "" && strings.EqualFold(a.Username, b.Username)) ||
+		(a.Email != "" && strings.EqualFold(a.Email, b.Email))
+}
+
+// appendUnique appends p unless the same person is already in pairs.
+func appendUnique(pairs []Pair, p Pair) []Pair {
+	for _, existing := range pairs {
+		if samePerson(existing, p) {
+			return pairs
+		}
+	}
+	return append(pairs, p)
+}
@@ -0,0 +1,73 @@
+package config
+
+import "time"
+
+const ActivityFileName = "activity.json"
+
+// Activity records when the pairs were last saved, so pairs left unchanged
+// for too long can be expired.
+type Activity struct {
+	Schema
+	Saved time.Time `json:"saved"`
+}
+
+// touch records that the pairs were just saved. Callers must hold the lock.
+func touch() error {
+	return saveJSON(ActivityFileName, &Activity{Saved: time.Now()})
+}
+
+// ExpirePairs clears the default and branch pairs if none of them have
+// been saved for longer than after, so a session's pairs don't carry over
+// to the next day. Clearing them is journaled and can be undone. It
+// reports whether any pairs were cleared.
+func ExpirePairs(after time.Duration) (bool, error) {
+	if after <= 0 {
+		return false, nil
+	}
+
+	var expired bool
+	err := withLock(func() error {
+		var activity Activity
+		if _, err := loadJSON(ActivityFileName, &activity); err != nil {
+			return err
+		}
+		if activity.Saved.IsZero() || time.Since(activity.Saved) < after {
+			return nil
+		}
+
+		branches, err := storage.Branches()
+		if err != nil {
+			return err
+		}
+		var stale []string
+		for _, branch := range append([]string{""}, branches...) {
+			config, found, err := storage.Load(branch)
+			if err != nil {
+				return err
+			}
+			if found && len(config.Pairs) > 0 {
+				stale = append(stale, branch)
+			}
+		}
+		if len(stale) == 0 {
+			return nil
+		}
+
+		if err := record("expire", stale...); err != nil {
+			return err
+		}
+		for _, branch := range stale {
+			if branch == "" {
+				err = SaveBranchPairs("", &PairsConfig{Pairs: []Pair{}})
+			} else {
+				err = deleteBranchPairs(branch)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		expired = true
+		return nil
+	})
+	return expired, err
+}
@@ -0,0 +1,239 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/omgitsads/gh-pair/internal/git"
+)
+
+// Bundle is a portable copy of a repository's pairing configuration and
+// the global roster, written by 'gh pair export' and read by 'gh pair
+// import'. Settings are filled in by the caller, since they are managed
+// outside this package.
+type Bundle struct {
+	Schema
+	Pairs    []Pair            `json:"pairs"`
+	Branches map[string][]Pair `json:"branches,omitempty"`
+	Recent   []Pair            `json:"recent"`
+	Pinned   []string          `json:"pinned,omitempty"`
+	Roster   []RosterEntry     `json:"roster"`
+	Settings *BundleSettings   `json:"settings,omitempty"`
+}
+
+// BundleSettings are the global settings carried in a bundle.
+type BundleSettings struct {
+	Theme      string `json:"theme,omitempty"`
+	RecentSize int    `json:"recentSize,omitempty"`
+}
+
+// ImportResult counts what an import changed.
+type ImportResult struct {
+	Pairs    int // pairs added to the default and branch sets
+	Branches int // branch sets created
+	Recent   int // recent pairs added
+	Roster   int // roster entries added
+}
+
+// Export returns the pairs, branch pairs and recent pairs of the current
+// repository, and the roster.
+func Export() (*Bundle, error) {
+	bundle := &Bundle{}
+	bundle.setVersion(SchemaVersion)
+
+	pairs, err := LoadBranchPairs("")
+	if err != nil {
+		return nil, err
+	}
+	bundle.Pairs = pairs.Pairs
+
+	branches, err := storage.Branches()
+	if err != nil {
+		return nil, err
+	}
+	for _, branch := range branches {
+		config, err := LoadBranchPairs(branch)
+		if err != nil {
+			return nil, err
+		}
+		if bundle.Branches == nil {
+			bundle.Branches = make(map[string][]Pair)
+		}
+		bundle.Branches[branch] = config.Pairs
+	}
+
+	recent, err := LoadRecent()
+	if err != nil {
+		return nil, err
+	}
+	bundle.Recent = recent.Recent
+	bundle.Pinned = recent.Pinned
+
+	roster, err := LoadRoster()
+	if err != nil {
+		return nil, err
+	}
+	bundle.Roster = roster.People
+
+	return bundle, nil
+}
+
+// ParseBundle decodes an exported bundle, upgrading older formats.
+func ParseBundle(name string, data []byte) (*Bundle, error) {
+	data, err := migrate(name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var bundle Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, err
+	}
+	return &bundle, nil
+}
+
+// Import loads a bundle into the current repository and the roster. By
+// default it merges: people missing locally are added and existing entries
+// are kept. With replace, the bundle's pairs, recent pairs and roster
+// replace the local ones. Changes to the default and branch pairs are
+// undone together with Undo; recent pairs and the roster aren't journaled.
+// Bundles may come from anyone, so branch names are checked before
+// anything is changed.
+func Import(bundle *Bundle, replace bool) (ImportResult, error) {
+	for branch := range bundle.Branches {
+		if err := checkImportBranch(branch); err != nil {
+			return ImportResult{}, err
+		}
+	}
+
+	var result ImportResult
+	err := withLock(func() error {
+		sets := map[string][]Pair{"": bundle.Pairs}
+		for branch, pairs := range bundle.Branches {
+			sets[branch] = pairs
+		}
+
+		// With replace, branch sets missing from the bundle are removed
+		var removed []string
+		if replace {
+			branches, err := storage.Branches()
+			if err != nil {
+				return err
+			}
+			for _, branch := range branches {
+				if _, ok := sets[branch]; !ok {
+					removed = append(removed, branch)
+				}
+			}
+		}
+
+		merged := make(map[string][]Pair)
+		for branch, pairs := range sets {
+			config, found, err := storage.Load(branch)
+			if err != nil {
+				return err
+			}
+
+			var added int
+			merged[branch], added = mergePairs(config.Pairs, pairs, replace)
+			if found && !replace && added == 0 {
+				delete(merged, branch)
+				continue
+			}
+			if !found && branch != "" {
+				result.Branches++
+			}
+			result.Pairs += added
+		}
+
+		// The whole import is undone in one step
+		changed := append(slices.Sorted(maps.Keys(merged)), removed...)
+		if len(changed) > 0 {
+			if err := record("import", changed...); err != nil {
+				return err
+			}
+		}
+		for _, branch := range removed {
+			if err := deleteBranchPairs(branch); err != nil {
+				return err
+			}
+		}
+		for branch, pairs := range merged {
+			if err := SaveBranchPairs(branch, &PairsConfig{Pairs: pairs}); err != nil {
+				return err
+			}
+		}
+
+		recent, err := LoadRecent()
+		if err != nil {
+			return err
+		}
+		var added int
+		recent.Recent, added = mergePairs(recent.Recent, bundle.Recent, replace)
+		result.Recent = added
+		if replace {
+			recent.Pinned = nil
+		}
+		for _, username := range bundle.Pinned {
+			if !recent.IsPinned(username) {
+				recent.Pinned = append(recent.Pinned, username)
+			}
+		}
+		recent.arrange(recentSize)
+		return SaveRecent(recent)
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Roster, err = ImportRoster(bundle.Roster, replace)
+	return result, err
+}
+
+// checkImportBranch rejects a branch name from a bundle that isn't a valid
+// branch, so it can't be used to write pairs outside the gh-pair directory.
+func checkImportBranch(branch string) error {
+	if strings.Contains(branch, "..") || filepath.IsAbs(branch) || strings.HasPrefix(branch, "/") {
+		return fmt.Errorf("invalid branch name %q in bundle", branch)
+	}
+	if err := git.CheckBranchName(branch); err != nil {
+		return fmt.Errorf("%w in bundle", err)
+	}
+	return nil
+}
+
+// mergePairs adds the incoming pairs missing from existing, or returns the
+// incoming pairs if replace is set. It returns the merged list and how many
+// pairs were new.
+func mergePairs(existing, incoming []Pair, replace bool) ([]Pair, int) {
+	merged := make([]Pair, 0, len(existing)+len(incoming))
+	if !replace {
+		merged = append(merged, existing...)
+	}
+
+	added := 0
+	for _, p := range incoming {
+		if containsPair(merged, p.Username) {
+			continue
+		}
+		if !containsPair(existing, p.Username) {
+			added++
+		}
+		merged = append(merged, p)
+	}
+	return merged, added
+}
+
+// containsPair reports whether pairs has an entry for username.
+func containsPair(pairs []Pair, username string) bool {
+	for _, p := range pairs {
+		if strings.EqualFold(p.Username, username) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,207 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+var (
+	alice = Pair{Username: "alice", Name: "Alice", Email: "alice@example.com"}
+	bob   = Pair{Username: "bob", Name: "Bob", Email: "bob@example.com"}
+	carol = Pair{Username: "carol", Name: "Carol", Email: "carol@example.com"}
+)
+
+func TestMergePairs(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  []Pair
+		incoming  []Pair
+		replace   bool
+		want      []Pair
+		wantAdded int
+	}{
+		{
+			name:      "adds missing pairs after existing ones",
+			existing:  []Pair{alice},
+			incoming:  []Pair{bob, carol},
+			want:      []Pair{alice, bob, carol},
+			wantAdded: 2,
+		},
+		{
+			name:     "keeps existing details",
+			existing: []Pair{alice},
+			incoming: []Pair{{Username: "ALICE", Name: "Someone Else", Email: "else@example.com"}},
+			want:     []Pair{alice},
+		},
+		{
+			name:      "duplicates in the incoming pairs are added once",
+			incoming:  []Pair{bob, bob},
+			want:      []Pair{bob},
+			wantAdded: 1,
+		},
+		{
+			name:      "replace drops pairs missing from the incoming ones",
+			existing:  []Pair{alice, bob},
+			incoming:  []Pair{bob, carol},
+			replace:   true,
+			want:      []Pair{bob, carol},
+			wantAdded: 1,
+		},
+		{
+			name:     "replace with nothing clears",
+			existing: []Pair{alice},
+			replace:  true,
+			want:     []Pair{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, added := mergePairs(tt.existing, tt.incoming, tt.replace)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergePairs() = %v, want %v", got, tt.want)
+			}
+			if added != tt.wantAdded {
+				t.Errorf("mergePairs() added = %d, want %d", added, tt.wantAdded)
+			}
+		})
+	}
+}
+
+func TestImport(t *testing.T) {
+	tests := []struct {
+		name         string
+		bundle       Bundle
+		replace      bool
+		wantErr      bool
+		wantDefault  []Pair
+		wantBranches map[string][]Pair
+	}{
+		{
+			name: "merges into the default and branch pairs",
+			bundle: Bundle{
+				Pairs:    []Pair{alice, carol},
+				Branches: map[string][]Pair{"feature/x": {carol}},
+			},
+			wantDefault: []Pair{alice, bob, carol},
+			wantBranches: map[string][]Pair{
+				"feature/x": {carol},
+				"old":       {bob},
+			},
+		},
+		{
+			name: "replace removes branch sets missing from the bundle",
+			bundle: Bundle{
+				Pairs:    []Pair{carol},
+				Branches: map[string][]Pair{"feature/x": {carol}},
+			},
+			replace:      true,
+			wantDefault:  []Pair{carol},
+			wantBranches: map[string][]Pair{"feature/x": {carol}},
+		},
+		{
+			name: "branch name escaping the config directory",
+			bundle: Bundle{
+				Pairs:    []Pair{carol},
+				Branches: map[string][]Pair{"../../hooks/x": {carol}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "absolute branch name",
+			bundle: Bundle{
+				Branches: map[string][]Pair{"/tmp/x": {carol}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "branch name git rejects",
+			bundle: Bundle{
+				Branches: map[string][]Pair{"bad name~1": {carol}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty branch name",
+			bundle: Bundle{
+				Branches: map[string][]Pair{"": {carol}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := setupRepo(t)
+			before := seedPairs(t)
+
+			_, err := Import(&tt.bundle, tt.replace)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Import() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				// Nothing may be written, inside the config directory or out
+				if got := loadAllPairs(t); !reflect.DeepEqual(got, before) {
+					t.Errorf("pairs after a failed import = %v, want %v", got, before)
+				}
+				if _, err := os.Stat(filepath.Join(dir, ".git", "hooks", "x.json")); err == nil {
+					t.Error("import wrote outside the gh-pair directory")
+				}
+				return
+			}
+
+			want := map[string][]Pair{"": tt.wantDefault}
+			for branch, pairs := range tt.wantBranches {
+				want[branch] = pairs
+			}
+			if got := loadAllPairs(t); !reflect.DeepEqual(got, want) {
+				t.Errorf("pairs after import = %v, want %v", got, want)
+			}
+
+			// The whole import is undone in one step
+			if _, err := Undo(); err != nil {
+				t.Fatalf("Undo() error = %v", err)
+			}
+			if got := loadAllPairs(t); !reflect.DeepEqual(got, before) {
+				t.Errorf("pairs after undoing the import = %v, want %v", got, before)
+			}
+		})
+	}
+}
+
+// seedPairs saves alice and bob as the default pairs and bob on branch
+// "old", and returns every set of pairs.
+func seedPairs(t *testing.T) map[string][]Pair {
+	t.Helper()
+	if _, err := AddPairs([]Pair{alice, bob}); err != nil {
+		t.Fatalf("AddPairs() error = %v", err)
+	}
+	if err := SaveBranchPairs("old", &PairsConfig{Pairs: []Pair{bob}}); err != nil {
+		t.Fatalf("SaveBranchPairs() error = %v", err)
+	}
+	return loadAllPairs(t)
+}
+
+// loadAllPairs returns the default and every branch set of pairs, by branch.
+func loadAllPairs(t *testing.T) map[string][]Pair {
+	t.Helper()
+	branches, err := storage.Branches()
+	if err != nil {
+		t.Fatalf("Branches() error = %v", err)
+	}
+	slices.Sort(branches)
+
+	sets := make(map[string][]Pair)
+	for _, branch := range append([]string{""}, branches...) {
+		config, err := LoadBranchPairs(branch)
+		if err != nil {
+			t.Fatalf("LoadBranchPairs(%q) error = %v", branch, err)
+		}
+		sets[branch] = config.Pairs
+	}
+	return sets
+}
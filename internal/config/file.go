@@ -0,0 +1,104 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/omgitsads/gh-pair/internal/fileutil"
+	"github.com/omgitsads/gh-pair/internal/git"
+)
+
+// loadJSON reads a file from the config directory into v, upgrading older
+// formats in memory; they are written in the current format on the next
+// save. It returns false if the file does not exist.
+func loadJSON(name string, v versioned) (bool, error) {
+	configDir, err := git.ConfigDir()
+	if err != nil {
+		return false, err
+	}
+	return loadJSONFile(configDir, name, v)
+}
+
+// loadJSONFile is loadJSON for a file in dir.
+func loadJSONFile(dir, name string, v versioned) (bool, error) {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	data, err = migrate(name, data)
+	if err != nil {
+		return false, err
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// saveJSON writes v to a file in the config directory in the current
+// format. It refuses to overwrite a file written by a newer version.
+func saveJSON(name string, v versioned) error {
+	configDir, err := git.EnsureConfigDir()
+	if err != nil {
+		return err
+	}
+	return saveJSONFile(configDir, name, v)
+}
+
+// saveJSONFile is saveJSON for a file in dir.
+func saveJSONFile(dir, name string, v versioned) error {
+	path := filepath.Join(dir, name)
+	if existing, err := os.ReadFile(path); err == nil {
+		var doc map[string]json.RawMessage
+		if json.Unmarshal(existing, &doc) == nil {
+			if version, err := fileVersion(name, doc); err == nil && version > SchemaVersion {
+				return &VersionError{File: name, Version: version}
+			}
+		}
+	}
+
+	// Branch pairs live in subdirectories, and the global directory
+	// may not exist yet
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	v.setVersion(SchemaVersion)
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return fileutil.WriteAtomic(path, data, 0644)
+}
+
+// globalDir returns the directory for files shared by all repositories
+// (~/.config/gh-pair).
+func globalDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "gh-pair"), nil
+}
+
+// removeFile deletes a file from the config directory if it exists.
+func removeFile(name string) error {
+	configDir, err := git.ConfigDir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filepath.Join(configDir, name)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+package config
+
+import (
+	"errors"
+	"time"
+
+	"github.com/omgitsads/gh-pair/internal/git"
+)
+
+const (
+	JournalFileName = "journal.json"
+
+	// maxJournalEntries is how many changes can be undone.
+	maxJournalEntries = 50
+)
+
+var (
+	ErrNothingToUndo = errors.New("nothing to undo")
+	ErrNothingToRedo = errors.New("nothing to redo")
+)
+
+// JournalEntry records a set of pairs as it was before a change, so the
+// change can be undone.
+type JournalEntry struct {
+	Action string    `json:"action"`           // e.g. "remove @octocat"
+	Branch string    `json:"branch,omitempty"` // "" for the default pairs
+	Pairs  []Pair    `json:"pairs"`
+	Exists bool      `json:"exists"` // false if the branch had no pairs of its own
+	Time   time.Time `json:"time"`
+	Batch  bool      `json:"batch,omitempty"` // undone together with the entry before it
+
+	// Session is set for driver and mob changes, which also change the
+	// author identity and the session files
+	Session *Session `json:"session,omitempty"`
+}
+
+// Session records the author identity and the driver or mob session, if
+// any, as they were before a change.
+type Session struct {
+	Identity git.Identity   `json:"identity"`
+	Driver   *DriverSession `json:"driver,omitempty"`
+	Mob      *MobSession    `json:"mob,omitempty"`
+}
+
+// currentSession returns the author identity and sessions as they are now.
+func currentSession() (*Session, error) {
+	driver, err := LoadDriver()
+	if err != nil {
+		return nil, err
+	}
+	mob, err := LoadMob()
+	if err != nil {
+		return nil, err
+	}
+	return &Session{Identity: git.LocalIdentity(), Driver: driver, Mob: mob}, nil
+}
+
+// restore puts the author identity and session files back as recorded.
+func (s *Session) restore() error {
+	if err := s.Identity.Restore(); err != nil {
+		return err
+	}
+
+	if s.Driver != nil {
+		if err := SaveDriver(s.Driver); err != nil {
+			return err
+		}
+	} else if err := removeFile(DriverFileName); err != nil {
+		return err
+	}
+
+	if s.Mob != nil {
+		return SaveMob(s.Mob)
+	}
+	return removeFile(MobFileName)
+}
+
+// Journal holds the changes that can be undone and redone.
+type Journal struct {
+	Schema
+	Undo []JournalEntry `json:"undo"`
+	Redo []JournalEntry `json:"redo"`
+}
+
+// LoadJournal loads the change journal.
+func LoadJournal() (*Journal, error) {
+	var journal Journal
+	if _, err := loadJSON(JournalFileName, &journal); err != nil {
+		return nil, err
+	}
+	return &journal, nil
+}
+
+// SaveJournal saves the change journal.
+func SaveJournal(journal *Journal) error {
+	return saveJSON(JournalFileName, journal)
+}
+
+// snapshot returns a journal entry holding a set of pairs as it is now in
+// storage s.
+func snapshot(s Storage, action, branch string) (JournalEntry, error) {
+	config, found, err := s.Load(branch)
+	if err != nil {
+		return JournalEntry{}, err
+	}
+
+	pairs := config.Pairs
+	if pairs == nil {
+		pairs = []Pair{}
+	}
+
+	return JournalEntry{
+		Action: action,
+		Branch: branch,
+		Pairs:  pairs,
+		Exists: found || branch == "",
+		Time:   time.Now(),
+	}, nil
+}
+
+// record journals sets of pairs before they are changed, so the change
+// can be undone as one step. Callers must hold the lock.
+func record(action string, branches ...string) error {
+	entries := make([]JournalEntry, len(branches))
+	for i, branch := range branches {
+		var err error
+		if entries[i], err = snapshot(storage, action, branch); err != nil {
+			return err
+		}
+	}
+
+	return addToJournal(entries)
+}
+
+// recordSession is record for driver and mob changes, journaling the
+// author identity and session files along with the branch's pairs.
+// Callers must hold the lock.
+func recordSession(action, branch string) error {
+	entry, err := snapshot(storage, action, branch)
+	if err != nil {
+		return err
+	}
+	if entry.Session, err = currentSession(); err != nil {
+		return err
+	}
+	return addToJournal([]JournalEntry{entry})
+}
+
+// addToJournal adds a change made of entries to the saved journal.
+func addToJournal(entries []JournalEntry) error {
+	journal, err := LoadJournal()
+	if err != nil {
+		return err
+	}
+	journal.add(entries)
+	return SaveJournal(journal)
+}
+
+// snapshotAll returns journal entries holding the default and every branch
+// set of pairs in storage s, for changes that replace them all.
+func snapshotAll(s Storage, action string) ([]JournalEntry, error) {
+	branches, err := s.Branches()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]JournalEntry, 0, len(branches)+1)
+	for _, branch := range append([]string{""}, branches...) {
+		entry, err := snapshot(s, action, branch)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// removeOthers adds entries that delete the branch sets in branches that
+// entries don't cover, so restoring them leaves only the recorded sets.
+func removeOthers(entries []JournalEntry, branches []string) []JournalEntry {
+	covered := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		covered[e.Branch] = true
+	}
+	for _, branch := range branches {
+		if !covered[branch] {
+			entries = append(entries, JournalEntry{
+				Action: entries[0].Action,
+				Branch: branch,
+				Pairs:  []Pair{},
+				Time:   time.Now(),
+			})
+		}
+	}
+	return entries
+}
+
+// add appends a change made of entries to the undo list. A new change
+// can't be redone past, so the redo list is cleared.
+func (j *Journal) add(entries []JournalEntry) {
+	for i := range entries {
+		entries[i].Batch = i > 0
+	}
+	j.Undo = append(j.Undo, entries...)
+	if len(j.Undo) > maxJournalEntries {
+		j.Undo = j.Undo[len(j.Undo)-maxJournalEntries:]
+	}
+	// Don't keep part of a change that was cut off
+	for len(j.Undo) > 0 && j.Undo[0].Batch {
+		j.Undo = j.Undo[1:]
+	}
+	j.Redo = nil
+}
+
+// restore puts a set of pairs, and the session if recorded, back as they
+// were.
+func restore(entry JournalEntry) error {
+	if entry.Session != nil {
+		if err := entry.Session.restore(); err != nil {
+			return err
+		}
+	}
+	if !entry.Exists {
+		return deleteBranchPairs(entry.Branch)
+	}
+	return SaveBranchPairs(entry.Branch, &PairsConfig{Pairs: entry.Pairs})
+}
+
+// Undo reverts the last change to the pairs and returns it.
+func Undo() (*JournalEntry, error) {
+	return step(func(j *Journal) *[]JournalEntry { return &j.Undo },
+		func(j *Journal) *[]JournalEntry { return &j.Redo }, ErrNothingToUndo)
+}
+
+// Redo reapplies the last undone change and returns it.
+func Redo() (*JournalEntry, error) {
+	return step(func(j *Journal) *[]JournalEntry { return &j.Redo },
+		func(j *Journal) *[]JournalEntry { return &j.Undo }, ErrNothingToRedo)
+}
+
+// step pops a change from one list, restores it, and pushes the state it
+// replaced onto the other list.
+func step(from, to func(*Journal) *[]JournalEntry, empty error) (*JournalEntry, error) {
+	var applied []JournalEntry
+	err := withLock(func() error {
+		journal, err := LoadJournal()
+		if err != nil {
+			return err
+		}
+
+		src, dst := from(journal), to(journal)
+		if len(*src) == 0 {
+			return empty
+		}
+
+		// A change is its first entry and the batched entries after it
+		start := len(*src) - 1
+		for start > 0 && (*src)[start].Batch {
+			start--
+		}
+		applied = (*src)[start:]
+		*src = (*src)[:start]
+
+		for _, entry := range applied {
+			current, err := snapshot(storage, entry.Action, entry.Branch)
+			if err != nil {
+				return err
+			}
+			if entry.Session != nil {
+				if current.Session, err = currentSession(); err != nil {
+					return err
+				}
+			}
+			if err := restore(entry); err != nil {
+				return err
+			}
+			current.Batch = entry.Batch
+			*dst = append(*dst, current)
+		}
+
+		return SaveJournal(journal)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &applied[0], nil
+}
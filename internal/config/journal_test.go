@@ -0,0 +1,168 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/omgitsads/gh-pair/internal/git"
+)
+
+func TestJournalAdd(t *testing.T) {
+	change := func(action string, n int) []JournalEntry {
+		entries := make([]JournalEntry, n)
+		for i := range entries {
+			entries[i] = JournalEntry{Action: action}
+		}
+		return entries
+	}
+
+	tests := []struct {
+		name      string
+		changes   [][]JournalEntry
+		wantLen   int
+		wantFirst string // action of the oldest entry kept
+	}{
+		{
+			name:      "batches are marked after their first entry",
+			changes:   [][]JournalEntry{change("a", 1), change("b", 3)},
+			wantLen:   4,
+			wantFirst: "a",
+		},
+		{
+			name:      "oldest changes are dropped past the limit",
+			changes:   [][]JournalEntry{change("a", 1), change("b", maxJournalEntries)},
+			wantLen:   maxJournalEntries,
+			wantFirst: "b",
+		},
+		{
+			name:      "a change cut in two is dropped whole",
+			changes:   [][]JournalEntry{change("a", 3), change("b", maxJournalEntries-1)},
+			wantLen:   maxJournalEntries - 1,
+			wantFirst: "b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &Journal{Redo: change("redo", 1)}
+			for _, c := range tt.changes {
+				j.add(c)
+			}
+
+			if len(j.Undo) != tt.wantLen {
+				t.Fatalf("len(Undo) = %d, want %d", len(j.Undo), tt.wantLen)
+			}
+			if j.Undo[0].Action != tt.wantFirst || j.Undo[0].Batch {
+				t.Errorf("oldest entry = %+v, want the start of change %q", j.Undo[0], tt.wantFirst)
+			}
+			for i := 1; i < len(j.Undo); i++ {
+				if want := j.Undo[i].Action == j.Undo[i-1].Action; j.Undo[i].Batch != want {
+					t.Errorf("Undo[%d].Batch = %v, want %v", i, j.Undo[i].Batch, want)
+				}
+			}
+			if j.Redo != nil {
+				t.Errorf("Redo = %v, want it cleared", j.Redo)
+			}
+		})
+	}
+}
+
+func TestUndoRedoBatch(t *testing.T) {
+	setupRepo(t)
+
+	if _, err := AddPairs([]Pair{alice}); err != nil {
+		t.Fatalf("AddPairs() error = %v", err)
+	}
+	before := loadAllPairs(t)
+
+	// One change to the default pairs and a new branch set
+	err := withLock(func() error {
+		if err := record("change both", "", "feature"); err != nil {
+			return err
+		}
+		if err := SaveBranchPairs("", &PairsConfig{Pairs: []Pair{bob}}); err != nil {
+			return err
+		}
+		return SaveBranchPairs("feature", &PairsConfig{Pairs: []Pair{carol}})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := loadAllPairs(t)
+
+	steps := []struct {
+		name string
+		step func() (*JournalEntry, error)
+		want map[string][]Pair
+	}{
+		{"undo the batch", Undo, before},
+		{"redo the batch", Redo, after},
+		{"undo it again", Undo, before},
+		{"undo adding alice", Undo, map[string][]Pair{"": {}}},
+	}
+	for _, s := range steps {
+		entry, err := s.step()
+		if err != nil {
+			t.Fatalf("%s: error = %v", s.name, err)
+		}
+		if entry.Batch {
+			t.Errorf("%s: returned entry %+v is not the start of a change", s.name, entry)
+		}
+		if got := loadAllPairs(t); !reflect.DeepEqual(got, s.want) {
+			t.Errorf("%s: pairs = %v, want %v", s.name, got, s.want)
+		}
+	}
+
+	if _, err := Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Undo() with nothing left: error = %v, want %v", err, ErrNothingToUndo)
+	}
+}
+
+func TestUndoDrive(t *testing.T) {
+	setupRepo(t)
+
+	owner := Pair{Username: "me", Name: "Me", Email: "me@example.com"}
+	if err := git.SetLocalIdentity(owner.Name, owner.Email); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AddPairs([]Pair{alice}); err != nil {
+		t.Fatalf("AddPairs() error = %v", err)
+	}
+	if _, err := Drive(alice, owner); err != nil {
+		t.Fatalf("Drive() error = %v", err)
+	}
+
+	steps := []struct {
+		name       string
+		step       func() (*JournalEntry, error)
+		wantEmail  string
+		wantDriver bool
+		wantPairs  []Pair
+	}{
+		{"undo", Undo, owner.Email, false, []Pair{alice}},
+		{"redo", Redo, alice.Email, true, []Pair{owner}},
+	}
+	for _, s := range steps {
+		if _, err := s.step(); err != nil {
+			t.Fatalf("%s: error = %v", s.name, err)
+		}
+		if email := git.LocalIdentity().Email; email != s.wantEmail {
+			t.Errorf("%s: user.email = %q, want %q", s.name, email, s.wantEmail)
+		}
+		session, err := LoadDriver()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (session != nil) != s.wantDriver {
+			t.Errorf("%s: driver session = %+v, want one: %v", s.name, session, s.wantDriver)
+		}
+		pairs, err := LoadPairs()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(pairs.Pairs, s.wantPairs) {
+			t.Errorf("%s: pairs = %v, want %v", s.name, pairs.Pairs, s.wantPairs)
+		}
+	}
+}
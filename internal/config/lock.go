@@ -0,0 +1,29 @@
+package config
+
+import (
+	"github.com/omgitsads/gh-pair/internal/fileutil"
+	"github.com/omgitsads/gh-pair/internal/git"
+)
+
+// withLock runs fn while holding an exclusive advisory lock on the config
+// directory, so read-modify-write operations from concurrent gh-pair
+// processes (or the TUI and a CLI command) don't overwrite each other.
+// Functions called with the lock held must not take it again.
+func withLock(fn func() error) error {
+	configDir, err := git.EnsureConfigDir()
+	if err != nil {
+		return err
+	}
+	return fileutil.WithLock(configDir, fn)
+}
+
+// withGlobalLock is withLock for the files shared by all repositories,
+// such as the roster. When both locks are needed, the repository's is
+// taken first.
+func withGlobalLock(fn func() error) error {
+	dir, err := globalDir()
+	if err != nil {
+		return err
+	}
+	return fileutil.WithLock(dir, fn)
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// setupRepo creates a git repository in a temporary directory, with HOME
+// pointing there too, and changes into it. It skips the test if git isn't
+// installed.
+func setupRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	if out, err := exec.Command("git", "init", "-q", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	t.Chdir(dir)
+	return dir
+}
+
+// TestConcurrentAddPair adds pairs from many goroutines at once, checking
+// that the lock keeps every one of them and that pairs.json parses
+// whenever it is read.
+func TestConcurrentAddPair(t *testing.T) {
+	dir := setupRepo(t)
+
+	const writers = 20
+	pairsFile := filepath.Join(dir, ".git", "gh-pair", PairsFileName)
+
+	done := make(chan struct{})
+	readErrs := make(chan error, 1)
+	go func() {
+		defer close(readErrs)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			data, err := os.ReadFile(pairsFile)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				readErrs <- err
+				return
+			}
+			var config PairsConfig
+			if err := json.Unmarshal(data, &config); err != nil {
+				readErrs <- fmt.Errorf("pairs.json doesn't parse: %w\n%s", err, data)
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			username := fmt.Sprintf("user%d", i)
+			errs <- AddPair(Pair{Username: username, Name: username, Email: username + "@example.com"})
+		}()
+	}
+	wg.Wait()
+	close(done)
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("AddPair: %v", err)
+		}
+	}
+	if err := <-readErrs; err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadPairs()
+	if err != nil {
+		t.Fatalf("LoadPairs: %v", err)
+	}
+	found := make(map[string]bool)
+	for _, p := range config.Pairs {
+		found[p.Username] = true
+	}
+	for i := range writers {
+		if username := fmt.Sprintf("user%d", i); !found[username] {
+			t.Errorf("%s was lost; got %d of %d pairs", username, len(config.Pairs), writers)
+		}
+	}
+}
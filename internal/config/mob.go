@@ -0,0 +1,246 @@
+package config
+
+import (
+	"errors"
+	"time"
+
+	"github.com/omgitsads/gh-pair/internal/git"
+)
+
+const MobFileName = "mob.json"
+
+var (
+	ErrMobActive   = errors.New("a mob session is already running; stop it with 'gh pair mob stop'")
+	ErrNoMob       = errors.New("no mob session running; start one with 'gh pair mob start'")
+	ErrMobTooSmall = errors.New("a mob needs at least two participants")
+)
+
+// MobSession is a mob programming session in which the driver rotates on
+// a timer. The driver authors commits and everyone else is a co-author.
+type MobSession struct {
+	Schema
+	Participants  []Pair       `json:"participants"`
+	Driver        int          `json:"driver"`   // index into Participants
+	Interval      string       `json:"interval"` // rotation interval, e.g. "10m"
+	RotatedAt     time.Time    `json:"rotatedAt"`
+	Original      git.Identity `json:"original"`         // identity to restore on stop
+	PreviousPairs []Pair       `json:"previousPairs"`    // pairs to restore on stop
+	Branch        string       `json:"branch,omitempty"` // pair set the session changes, "" for the default
+}
+
+// CurrentDriver returns the participant who is currently driving.
+func (s *MobSession) CurrentDriver() Pair {
+	return s.Participants[s.Driver%len(s.Participants)]
+}
+
+// NextDriver returns the participant who drives after the current one.
+func (s *MobSession) NextDriver() Pair {
+	return s.Participants[(s.Driver+1)%len(s.Participants)]
+}
+
+// Navigators returns every participant except the driver.
+func (s *MobSession) Navigators() []Pair {
+	navigators := make([]Pair, 0, len(s.Participants)-1)
+	for i, p := range s.Participants {
+		if i != s.Driver%len(s.Participants) {
+			navigators = append(navigators, p)
+		}
+	}
+	return navigators
+}
+
+// RotationInterval returns the parsed rotation interval.
+func (s *MobSession) RotationInterval() time.Duration {
+	d, err := time.ParseDuration(s.Interval)
+	if err != nil || d <= 0 {
+		return 10 * time.Minute
+	}
+	return d
+}
+
+// NextRotation returns when the driver is next due to change.
+func (s *MobSession) NextRotation() time.Time {
+	return s.RotatedAt.Add(s.RotationInterval())
+}
+
+// apply makes the current driver the commit author and credits the navigators.
+func (s *MobSession) apply() error {
+	driver := s.CurrentDriver()
+	if err := git.SetLocalIdentity(driver.Name, driver.Email); err != nil {
+		return err
+	}
+	return SaveBranchPairs(s.Branch, &PairsConfig{Pairs: s.Navigators()})
+}
+
+// LoadMob loads the running mob session, or returns nil if there is none.
+func LoadMob() (*MobSession, error) {
+	var session MobSession
+	found, err := loadJSON(MobFileName, &session)
+	if err != nil || !found || len(session.Participants) == 0 {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// SaveMob saves the mob session.
+func SaveMob(session *MobSession) error {
+	return saveJSON(MobFileName, session)
+}
+
+// StartMob starts a mob session with the first participant driving.
+func StartMob(participants []Pair, interval time.Duration) (*MobSession, error) {
+	var session *MobSession
+	err := withLock(func() error {
+		var err error
+		session, err = startMob(participants, interval)
+		return err
+	})
+	return session, err
+}
+
+func startMob(participants []Pair, interval time.Duration) (*MobSession, error) {
+	existing, err := LoadMob()
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrMobActive
+	}
+
+	driver, err := LoadDriver()
+	if err != nil {
+		return nil, err
+	}
+	if driver != nil {
+		return nil, ErrDriverActive
+	}
+
+	if len(participants) < 2 {
+		return nil, ErrMobTooSmall
+	}
+
+	branch := ActiveBranch()
+	pairs, err := LoadBranchPairs(branch)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &MobSession{
+		Participants:  participants,
+		Interval:      interval.String(),
+		RotatedAt:     time.Now(),
+		Original:      git.LocalIdentity(),
+		PreviousPairs: pairs.Pairs,
+		Branch:        branch,
+	}
+
+	if err := recordSession("start mob", branch); err != nil {
+		return nil, err
+	}
+	if err := SaveMob(session); err != nil {
+		return nil, err
+	}
+	if err := session.apply(); err != nil {
+		// Don't leave a session behind that never took effect
+		session.Original.Restore()
+		removeFile(MobFileName)
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// RotateMob hands the keyboard to the next participant.
+func RotateMob() (*MobSession, error) {
+	var session *MobSession
+	err := withLock(func() error {
+		var err error
+		session, err = rotateMob()
+		return err
+	})
+	return session, err
+}
+
+// RotateMobIfDue rotates the driver if the rotation interval has passed,
+// checking under the lock so countdowns running in several terminals
+// rotate only once. It reports whether the driver changed.
+func RotateMobIfDue() (*MobSession, bool, error) {
+	var session *MobSession
+	var rotated bool
+	err := withLock(func() error {
+		var err error
+		session, err = LoadMob()
+		if err != nil {
+			return err
+		}
+		if session == nil {
+			return ErrNoMob
+		}
+		if time.Now().Before(session.NextRotation()) {
+			return nil
+		}
+		session, err = rotateMob()
+		rotated = err == nil
+		return err
+	})
+	return session, rotated, err
+}
+
+func rotateMob() (*MobSession, error) {
+	session, err := LoadMob()
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrNoMob
+	}
+
+	session.Driver = (session.Driver + 1) % len(session.Participants)
+	session.RotatedAt = time.Now()
+
+	if err := recordSession("rotate mob to "+session.CurrentDriver().Name, session.Branch); err != nil {
+		return nil, err
+	}
+
+	if err := SaveMob(session); err != nil {
+		return nil, err
+	}
+	if err := session.apply(); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// StopMob ends the mob session, restoring the original author identity and pairs.
+func StopMob() error {
+	return withLock(stopMob)
+}
+
+func stopMob() error {
+	session, err := LoadMob()
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return ErrNoMob
+	}
+
+	if err := recordSession("stop mob", session.Branch); err != nil {
+		return err
+	}
+	if err := session.Original.Restore(); err != nil {
+		return err
+	}
+
+	previous := session.PreviousPairs
+	if previous == nil {
+		previous = []Pair{}
+	}
+	if err := SaveBranchPairs(session.Branch, &PairsConfig{Pairs: previous}); err != nil {
+		return err
+	}
+
+	return removeFile(MobFileName)
+}
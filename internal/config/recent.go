@@ -0,0 +1,220 @@
+package config
+
+import (
+	"errors"
+	"strings"
+)
+
+// DefaultRecentSize is the number of unpinned recent pairs kept by default.
+const DefaultRecentSize = 10
+
+var (
+	ErrNotRecent = errors.New("not in the recent list")
+	ErrNotPinned = errors.New("not pinned")
+)
+
+// recentSize is the number of unpinned recent pairs kept.
+var recentSize = DefaultRecentSize
+
+// SetRecentSize sets how many unpinned recent pairs are kept. Values below
+// one restore the default.
+func SetRecentSize(n int) {
+	if n < 1 {
+		n = DefaultRecentSize
+	}
+	recentSize = n
+}
+
+// RecentSize returns how many unpinned recent pairs are kept.
+func RecentSize() int {
+	return recentSize
+}
+
+// IsPinned reports whether a user is pinned to the top of the recent list.
+func (c *RecentConfig) IsPinned(username string) bool {
+	for _, u := range c.Pinned {
+		if strings.EqualFold(u, username) {
+			return true
+		}
+	}
+	return false
+}
+
+// arrange moves pinned pairs to the top, keeping their order, drops pins
+// for users no longer in the list and trims the unpinned pairs to size.
+func (c *RecentConfig) arrange(size int) {
+	var pinned, unpinned []Pair
+	for _, p := range c.Recent {
+		if c.IsPinned(p.Username) {
+			pinned = append(pinned, p)
+		} else if len(unpinned) < size {
+			unpinned = append(unpinned, p)
+		}
+	}
+
+	pins := make([]string, 0, len(pinned))
+	for _, p := range pinned {
+		pins = append(pins, p.Username)
+	}
+
+	c.Recent = append(pinned, unpinned...)
+	if c.Recent == nil {
+		c.Recent = []Pair{}
+	}
+	c.Pinned = pins
+	if len(c.Pinned) == 0 {
+		c.Pinned = nil
+	}
+}
+
+// LoadRecent loads the recent pairs from the config file.
+func LoadRecent() (*RecentConfig, error) {
+	var config RecentConfig
+	if _, err := loadJSON(RecentFileName, &config); err != nil {
+		return nil, err
+	}
+
+	if config.Recent == nil {
+		config.Recent = []Pair{}
+	}
+
+	return &config, nil
+}
+
+// SaveRecent saves the recent pairs configuration.
+func SaveRecent(config *RecentConfig) error {
+	return saveJSON(RecentFileName, config)
+}
+
+// AddToRecent adds a pair to the recent list (moves to front if exists).
+func AddToRecent(pair Pair) error {
+	return withLock(func() error {
+		return addToRecent(pair)
+	})
+}
+
+// addToRecent is AddToRecent for callers already holding the lock.
+func addToRecent(pair Pair) error {
+	config, err := LoadRecent()
+	if err != nil {
+		return err
+	}
+
+	// Remove if already exists
+	newRecent := make([]Pair, 0, len(config.Recent)+1)
+	for _, p := range config.Recent {
+		if p.Username != pair.Username {
+			newRecent = append(newRecent, p)
+		}
+	}
+
+	// Add to front, behind any pinned pairs
+	config.Recent = append([]Pair{pair}, newRecent...)
+	config.arrange(recentSize)
+
+	return SaveRecent(config)
+}
+
+// RemoveRecent removes a user from the recent list, unpinning them.
+func RemoveRecent(username string) (Pair, error) {
+	var removed Pair
+	err := withLock(func() error {
+		config, err := LoadRecent()
+		if err != nil {
+			return err
+		}
+
+		found := false
+		newRecent := make([]Pair, 0, len(config.Recent))
+		for _, p := range config.Recent {
+			if strings.EqualFold(p.Username, username) {
+				removed = p
+				found = true
+				continue
+			}
+			newRecent = append(newRecent, p)
+		}
+		if !found {
+			return ErrNotRecent
+		}
+
+		config.Recent = newRecent
+		config.arrange(recentSize)
+		return SaveRecent(config)
+	})
+	return removed, err
+}
+
+// ClearRecent empties the recent list, keeping pinned pairs.
+func ClearRecent() error {
+	return withLock(func() error {
+		config, err := LoadRecent()
+		if err != nil {
+			return err
+		}
+
+		config.arrange(0)
+		return SaveRecent(config)
+	})
+}
+
+// PinRecent pins a pair to the top of the recent list, adding it if needed.
+func PinRecent(pair Pair) error {
+	return withLock(func() error {
+		config, err := LoadRecent()
+		if err != nil {
+			return err
+		}
+
+		if !config.IsPinned(pair.Username) {
+			config.Pinned = append(config.Pinned, pair.Username)
+		}
+
+		found := false
+		for _, p := range config.Recent {
+			if strings.EqualFold(p.Username, pair.Username) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			config.Recent = append(config.Recent, pair)
+		}
+
+		config.arrange(recentSize)
+		return SaveRecent(config)
+	})
+}
+
+// UnpinRecent unpins a user, leaving them in the recent list.
+func UnpinRecent(username string) error {
+	return withLock(func() error {
+		config, err := LoadRecent()
+		if err != nil {
+			return err
+		}
+		if !config.IsPinned(username) {
+			return ErrNotPinned
+		}
+
+		pins := make([]string, 0, len(config.Pinned))
+		for _, u := range config.Pinned {
+			if !strings.EqualFold(u, username) {
+				pins = append(pins, u)
+			}
+		}
+		config.Pinned = pins
+
+		// Keep the unpinned user at the front of the recent pairs
+		for i, p := range config.Recent {
+			if strings.EqualFold(p.Username, username) {
+				rest := append(append([]Pair{}, config.Recent[:i]...), config.Recent[i+1:]...)
+				config.Recent = append([]Pair{p}, rest...)
+				break
+			}
+		}
+
+		config.arrange(recentSize)
+		return SaveRecent(config)
+	})
+}
@@ -0,0 +1,287 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const RosterFileName = "roster.json"
+
+// Roster holds people's details as you want them credited, for example a
+// corrected name or a work email. It is shared by all repositories and
+// overrides GitHub's data whenever the person is added as a pair.
+type Roster struct {
+	Schema
+	People []RosterEntry `json:"people"`
+}
+
+// RosterEntry is a person in the roster. Aliases, such as initials
+// imported from other pairing tools, can be used in place of the username.
+type RosterEntry struct {
+	Pair
+	Aliases []string `json:"aliases,omitempty"`
+	Guessed bool     `json:"guessed,omitempty"` // Username comes from the email, not GitHub
+}
+
+// same reports whether two entries are the same person. A guessed
+// username may belong to someone else on GitHub, so entries with one are
+// told apart by email.
+func (e RosterEntry) same(other RosterEntry) bool {
+	if e.Guessed || other.Guessed {
+		return e.Guessed && other.Guessed && strings.EqualFold(e.Email, other.Email)
+	}
+	return strings.EqualFold(e.Username, other.Username)
+}
+
+// Find returns the roster entry for a GitHub username. Guessed usernames
+// are never matched.
+func (r *Roster) Find(username string) (Pair, bool) {
+	username = strings.TrimPrefix(username, "@")
+	for _, e := range r.People {
+		if !e.Guessed && strings.EqualFold(e.Username, username) {
+			return e.Pair, true
+		}
+	}
+	return Pair{}, false
+}
+
+// Guess returns the roster entry whose username was guessed as name, for
+// when name isn't a GitHub user.
+func (r *Roster) Guess(name string) (Pair, bool) {
+	name = strings.TrimPrefix(name, "@")
+	for _, e := range r.People {
+		if e.Guessed && strings.EqualFold(e.Username, name) {
+			return e.Pair, true
+		}
+	}
+	return Pair{}, false
+}
+
+// Resolve returns the roster entry with the given username or alias.
+func (r *Roster) Resolve(name string) (Pair, bool) {
+	if pair, ok := r.Find(name); ok {
+		return pair, true
+	}
+	name = strings.TrimPrefix(name, "@")
+	for _, e := range r.People {
+		for _, alias := range e.Aliases {
+			if strings.EqualFold(alias, name) {
+				return e.Pair, true
+			}
+		}
+	}
+	return Pair{}, false
+}
+
+// Apply returns pair with the name and email from its roster entry, if any.
+func (r *Roster) Apply(pair Pair) Pair {
+	entry, ok := r.Find(pair.Username)
+	if !ok {
+		return pair
+	}
+	if entry.Name != "" {
+		pair.Name = entry.Name
+	}
+	if entry.Email != "" {
+		pair.Email = entry.Email
+	}
+	return pair
+}
+
+// Set adds or replaces the roster entry for pair's username, keeping its
+// aliases.
+func (r *Roster) Set(pair Pair) {
+	for i, e := range r.People {
+		if !e.Guessed && strings.EqualFold(e.Username, pair.Username) {
+			r.People[i].Pair = pair
+			return
+		}
+	}
+	r.People = append(r.People, RosterEntry{Pair: pair})
+}
+
+// Merge adds entries to the roster, or replaces the roster with them if
+// replace is set. Existing people keep their details and gain any new
+// aliases. It returns how many people were added.
+func (r *Roster) Merge(entries []RosterEntry, replace bool) int {
+	existing := r.People
+	if replace {
+		r.People = nil
+	}
+
+	added := 0
+	for _, entry := range entries {
+		i := slices.IndexFunc(r.People, entry.same)
+		if i < 0 {
+			if !slices.ContainsFunc(existing, entry.same) {
+				added++
+			}
+			r.People = append(r.People, entry)
+			continue
+		}
+
+		for _, alias := range entry.Aliases {
+			if !slices.Contains(r.People[i].Aliases, alias) {
+				r.People[i].Aliases = append(r.People[i].Aliases, alias)
+			}
+		}
+	}
+
+	if r.People == nil {
+		r.People = []RosterEntry{}
+	}
+	return added
+}
+
+// LoadRoster loads the roster from ~/.config/gh-pair/roster.json.
+func LoadRoster() (*Roster, error) {
+	dir, err := globalDir()
+	if err != nil {
+		return nil, err
+	}
+
+	var roster Roster
+	if _, err := loadJSONFile(dir, RosterFileName, &roster); err != nil {
+		return nil, err
+	}
+
+	if roster.People == nil {
+		roster.People = []RosterEntry{}
+	}
+
+	return &roster, nil
+}
+
+// SaveRoster saves the roster.
+func SaveRoster(roster *Roster) error {
+	dir, err := globalDir()
+	if err != nil {
+		return err
+	}
+	return saveJSONFile(dir, RosterFileName, roster)
+}
+
+// ImportRoster adds people to the roster, or replaces it with them. It
+// returns how many people were added.
+func ImportRoster(entries []RosterEntry, replace bool) (int, error) {
+	added := 0
+	err := updateRoster(func(roster *Roster) {
+		added = roster.Merge(entries, replace)
+	})
+	return added, err
+}
+
+// updateRoster loads the roster, changes it with fn and saves it, holding
+// the global lock throughout.
+func updateRoster(fn func(*Roster)) error {
+	return withGlobalLock(func() error {
+		roster, err := LoadRoster()
+		if err != nil {
+			return err
+		}
+		fn(roster)
+		return SaveRoster(roster)
+	})
+}
+
+// ResolveRoster returns the roster entry with the given username or alias.
+func ResolveRoster(name string) (Pair, bool) {
+	roster, err := LoadRoster()
+	if err != nil {
+		return Pair{}, false
+	}
+	return roster.Resolve(name)
+}
+
+// GuessRoster returns the roster entry whose username was guessed as name.
+func GuessRoster(name string) (Pair, bool) {
+	roster, err := LoadRoster()
+	if err != nil {
+		return Pair{}, false
+	}
+	return roster.Guess(name)
+}
+
+// ApplyRoster returns pair with any roster override applied. An unreadable
+// roster is ignored so that adding pairs keeps working.
+func ApplyRoster(pair Pair) Pair {
+	roster, err := LoadRoster()
+	if err != nil {
+		return pair
+	}
+	return roster.Apply(pair)
+}
+
+// EditPair changes the name and email of a person. The change is made to
+// the default and branch pairs and the recent list of this repository, and
+// remembered in the roster so future adds use it.
+func EditPair(pair Pair) error {
+	if strings.TrimSpace(pair.Name) == "" {
+		return errors.New("name can't be empty")
+	}
+	if !strings.Contains(pair.Email, "@") || strings.ContainsAny(pair.Email, " <>") {
+		return fmt.Errorf("invalid email address: %q", pair.Email)
+	}
+
+	err := withLock(func() error {
+		branches, err := storage.Branches()
+		if err != nil {
+			return err
+		}
+
+		var changed []string
+		configs := make(map[string]*PairsConfig)
+		for _, branch := range append([]string{""}, branches...) {
+			config, found, err := storage.Load(branch)
+			if err != nil {
+				return err
+			}
+			if found && replacePair(config.Pairs, pair) {
+				changed = append(changed, branch)
+				configs[branch] = config
+			}
+		}
+
+		if len(changed) > 0 {
+			if err := record("edit @"+pair.Username, changed...); err != nil {
+				return err
+			}
+		}
+		for _, branch := range changed {
+			if err := SaveBranchPairs(branch, configs[branch]); err != nil {
+				return err
+			}
+		}
+
+		recent, err := LoadRecent()
+		if err != nil {
+			return err
+		}
+		if replacePair(recent.Recent, pair) {
+			return SaveRecent(recent)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return updateRoster(func(roster *Roster) {
+		roster.Set(pair)
+	})
+}
+
+// replacePair replaces the entry with pair's username and reports whether
+// there was one.
+func replacePair(pairs []Pair, pair Pair) bool {
+	replaced := false
+	for i, p := range pairs {
+		if strings.EqualFold(p.Username, pair.Username) {
+			pairs[i] = pair
+			replaced = true
+		}
+	}
+	return replaced
+}
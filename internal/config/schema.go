@@ -0,0 +1,95 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SchemaVersion is the version of the config file format this binary reads
+// and writes. Bump it and add a migration whenever a change would be
+// misread by older versions.
+const SchemaVersion = 1
+
+// Schema carries the format version of a config file. It is embedded in
+// every struct saved to the config directory.
+type Schema struct {
+	Version int `json:"version"`
+}
+
+func (s *Schema) setVersion(v int) { s.Version = v }
+
+// versioned is implemented by structs that embed Schema.
+type versioned interface {
+	setVersion(int)
+}
+
+// VersionError is returned when a config file was written by a newer
+// version of gh-pair than this one, or records a version that can't exist.
+type VersionError struct {
+	File    string
+	Version int
+}
+
+func (e *VersionError) Error() string {
+	if e.Version < 0 {
+		return fmt.Sprintf("%s has an invalid format version %d", e.File, e.Version)
+	}
+	return fmt.Sprintf("%s was written by a newer version of gh-pair (format version %d, this version supports up to %d); "+
+		"upgrade with 'gh extension upgrade pair'", e.File, e.Version, SchemaVersion)
+}
+
+// migration upgrades a decoded config file by one version in place.
+type migration func(name string, doc map[string]json.RawMessage) error
+
+// migrations[i] upgrades a file from version i to version i+1.
+var migrations = []migration{
+	// 0 -> 1: files written before versioning have the same layout
+	func(name string, doc map[string]json.RawMessage) error { return nil },
+}
+
+// migrate decodes data, upgrades it to SchemaVersion and returns the
+// upgraded JSON. Files newer than SchemaVersion are refused.
+func migrate(name string, data []byte) ([]byte, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	version, err := fileVersion(name, doc)
+	if err != nil {
+		return nil, err
+	}
+	if version == SchemaVersion {
+		return data, nil
+	}
+	if version > SchemaVersion {
+		return nil, &VersionError{File: name, Version: version}
+	}
+
+	for v := version; v < SchemaVersion; v++ {
+		if err := migrations[v](name, doc); err != nil {
+			return nil, fmt.Errorf("failed to upgrade %s from version %d: %w", name, v, err)
+		}
+	}
+
+	doc["version"] = json.RawMessage(fmt.Sprint(SchemaVersion))
+	return json.Marshal(doc)
+}
+
+// fileVersion returns the version recorded in a decoded file, or 0 for
+// files written before versioning.
+func fileVersion(name string, doc map[string]json.RawMessage) (int, error) {
+	raw, ok := doc["version"]
+	if !ok {
+		return 0, nil
+	}
+
+	var version int
+	if err := json.Unmarshal(raw, &version); err != nil {
+		return 0, fmt.Errorf("invalid version in %s: %w", name, err)
+	}
+	if version < 0 {
+		return 0, &VersionError{File: name, Version: version}
+	}
+	return version, nil
+}
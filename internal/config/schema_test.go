@@ -0,0 +1,98 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		wantVersion int
+		wantErr     bool
+		wantVersErr int // version in the expected *VersionError, if any
+	}{
+		{
+			name:        "current version is unchanged",
+			data:        `{"version": 1, "pairs": []}`,
+			wantVersion: 1,
+		},
+		{
+			name:        "unversioned file is upgraded",
+			data:        `{"pairs": [{"username": "alice"}]}`,
+			wantVersion: SchemaVersion,
+		},
+		{
+			name:        "newer version is refused",
+			data:        `{"version": 99, "pairs": []}`,
+			wantErr:     true,
+			wantVersErr: 99,
+		},
+		{
+			name:        "negative version is refused",
+			data:        `{"version": -1, "pairs": []}`,
+			wantErr:     true,
+			wantVersErr: -1,
+		},
+		{
+			name:    "version that isn't a number",
+			data:    `{"version": "one"}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid JSON",
+			data:    `{"version": `,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := migrate("pairs.json", []byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("migrate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var versionErr *VersionError
+			if isVersionErr := errors.As(err, &versionErr); isVersionErr != (tt.wantVersErr != 0) {
+				t.Fatalf("migrate() error = %v, want a VersionError: %v", err, tt.wantVersErr != 0)
+			} else if isVersionErr && versionErr.Version != tt.wantVersErr {
+				t.Errorf("VersionError.Version = %d, want %d", versionErr.Version, tt.wantVersErr)
+			}
+			if err != nil {
+				return
+			}
+
+			var doc struct {
+				Schema
+				Pairs []Pair `json:"pairs"`
+			}
+			if err := json.Unmarshal(got, &doc); err != nil {
+				t.Fatalf("migrate() returned invalid JSON %s: %v", got, err)
+			}
+			if doc.Version != tt.wantVersion {
+				t.Errorf("version = %d, want %d", doc.Version, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestVersionErrorMessage(t *testing.T) {
+	tests := []struct {
+		version int
+		want    string
+	}{
+		{version: 2, want: "written by a newer version"},
+		{version: -3, want: "invalid format version -3"},
+	}
+
+	for _, tt := range tests {
+		err := &VersionError{File: "recent.json", Version: tt.version}
+		if !strings.Contains(err.Error(), tt.want) || !strings.HasPrefix(err.Error(), "recent.json") {
+			t.Errorf("VersionError{Version: %d}.Error() = %q, want it to mention %q", tt.version, err.Error(), tt.want)
+		}
+	}
+}
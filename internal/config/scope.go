@@ -0,0 +1,125 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/omgitsads/gh-pair/internal/git"
+)
+
+// SwitchScope changes whether pairs are shared by all worktrees or kept
+// separately per worktree. Default pairs, branch pairs and recent pairs are
+// copied to the new location unless it already has its own.
+func SwitchScope(scope string) error {
+	if scope != git.ScopeShared && scope != git.ScopeWorktree {
+		return fmt.Errorf("unknown scope %q (expected %s or %s)", scope, git.ScopeShared, git.ScopeWorktree)
+	}
+	if git.PairScope() == scope {
+		return nil
+	}
+
+	return withLock(func() error {
+		sets := make(map[string]*PairsConfig)
+		branches, err := storage.Branches()
+		if err != nil {
+			return err
+		}
+		for _, branch := range append([]string{""}, branches...) {
+			if sets[branch], err = LoadBranchPairs(branch); err != nil {
+				return err
+			}
+		}
+
+		recent, err := LoadRecent()
+		if err != nil {
+			return err
+		}
+
+		// The journal moves too, so undo picks up where it left off
+		journal, err := LoadJournal()
+		if err != nil {
+			return err
+		}
+		entries, err := snapshotAll(storage, "switch scope to "+scope)
+		if err != nil {
+			return err
+		}
+
+		if err := git.SetPairScope(scope); err != nil {
+			return err
+		}
+
+		// Undoing the switch brings back the pairs that applied before it.
+		// Pairs in git config stay where they are, so there's nothing to undo.
+		if _, ok := storage.(JSONStorage); ok {
+			existing, err := storage.Branches()
+			if err != nil {
+				return err
+			}
+			journal.add(removeOthers(entries, existing))
+		}
+		if err := SaveJournal(journal); err != nil {
+			return err
+		}
+
+		// Pairs in git config don't live in the gh-pair directory
+		if _, ok := storage.(JSONStorage); ok {
+			for branch, config := range sets {
+				if _, found, err := storage.Load(branch); err != nil {
+					return err
+				} else if !found {
+					if err := SaveBranchPairs(branch, config); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		if found, err := loadJSON(RecentFileName, &RecentConfig{}); err != nil {
+			return err
+		} else if !found {
+			if err := SaveRecent(recent); err != nil {
+				return err
+			}
+		}
+		return touch()
+	})
+}
+
+// KeepLegacyScope keeps pairs per worktree when no scope has been chosen
+// and this linked worktree has pairs from a version that always kept them
+// in the worktree's own git directory. It reports whether the scope was set.
+func KeepLegacyScope() (bool, error) {
+	if git.HasPairScope() {
+		return false, nil
+	}
+
+	gitDir, err := git.GitDir()
+	if err != nil {
+		return false, err
+	}
+	commonDir, err := git.CommonDir()
+	if err != nil {
+		return false, err
+	}
+	if filepath.Clean(gitDir) == filepath.Clean(commonDir) {
+		return false, nil
+	}
+
+	if _, err := os.Stat(filepath.Join(gitDir, "gh-pair")); err != nil {
+		return false, nil
+	}
+	if err := git.SetPairScope(git.ScopeWorktree); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ScopeDescription describes where the current pairs apply.
+func ScopeDescription() string {
+	if git.PairScope() == git.ScopeWorktree {
+		return "this worktree only"
+	}
+	return "all worktrees"
+}
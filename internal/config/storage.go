@@ -0,0 +1,384 @@
+package config
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/omgitsads/gh-pair/internal/git"
+)
+
+const (
+	StorageJSON      = "json"
+	StorageGitConfig = "gitconfig"
+)
+
+// Storage persists pairs. There is a default set of pairs, plus optional
+// sets scoped to a branch; branch "" means the default set. LoadPairs and
+// SavePairs go through the storage selected with UseStorage.
+type Storage interface {
+	// Name describes the backend for display, e.g. "gitconfig (local)".
+	Name() string
+	// Load returns a set of pairs and whether it exists.
+	Load(branch string) (*PairsConfig, bool, error)
+	Save(branch string, config *PairsConfig) error
+	// Delete removes a branch's set, so the default pairs apply again.
+	Delete(branch string) error
+	// Branches lists the branches that have their own set of pairs.
+	Branches() ([]string, error)
+}
+
+// Git config keys in the repository's own config recording its backend.
+// The hooks read the backend from here too.
+const (
+	storageConfigKey      = "gh-pair.storage"
+	storageScopeConfigKey = "gh-pair.storagescope"
+)
+
+// storage is the backend used for pairs; JSON unless configured otherwise.
+var storage Storage = JSONStorage{}
+
+// UseStorage selects the backend used to load and save pairs.
+func UseStorage(s Storage) {
+	storage = s
+}
+
+// CurrentStorage returns the backend used to load and save pairs.
+func CurrentStorage() Storage {
+	return storage
+}
+
+// RepoStorage returns the backend chosen for the current repository with
+// SwitchStorage, or JSON if none was chosen.
+func RepoStorage() (Storage, error) {
+	name, _ := git.ConfigGetLocal(storageConfigKey)
+	scope, _ := git.ConfigGetLocal(storageScopeConfigKey)
+	return NewStorage(name, scope)
+}
+
+// setRepoStorage records s as the current repository's backend. JSON is
+// recorded too, so KeepLegacyStorage leaves the choice alone.
+func setRepoStorage(s Storage) error {
+	if gc, ok := s.(GitConfigStorage); ok {
+		if err := git.ConfigSetLocal(storageConfigKey, StorageGitConfig); err != nil {
+			return err
+		}
+		return git.ConfigSetLocal(storageScopeConfigKey, gc.Scope)
+	}
+	if err := git.ConfigSetLocal(storageConfigKey, StorageJSON); err != nil {
+		return err
+	}
+	return git.ConfigUnsetLocal(storageScopeConfigKey)
+}
+
+// KeepLegacyStorage records the backend an older version chose for all
+// repositories as this repository's backend, if it hasn't chosen one, so
+// pairs already stored there aren't lost. A repository whose pairs were
+// left in pairs.json keeps using it. It reports whether the older
+// backend was kept.
+func KeepLegacyStorage(name, scope string) (bool, error) {
+	if name == "" || name == StorageJSON {
+		return false, nil
+	}
+	if _, ok := git.ConfigGetLocal(storageConfigKey); ok {
+		return false, nil
+	}
+
+	legacy, err := NewStorage(name, scope)
+	if err != nil {
+		return false, err
+	}
+	inLegacy, err := hasPairs(legacy)
+	if err != nil {
+		return false, err
+	}
+	inJSON, err := hasPairs(JSONStorage{})
+	if err != nil {
+		return false, err
+	}
+	if inJSON && !inLegacy {
+		return false, setRepoStorage(JSONStorage{})
+	}
+
+	if err := setRepoStorage(legacy); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// hasPairs reports whether s holds default or branch pairs.
+func hasPairs(s Storage) (bool, error) {
+	branches, err := s.Branches()
+	if err != nil || len(branches) > 0 {
+		return len(branches) > 0, err
+	}
+	config, _, err := s.Load("")
+	if err != nil {
+		return false, err
+	}
+	return len(config.Pairs) > 0, nil
+}
+
+// NewStorage returns the backend with the given name. scope is only used by
+// the git config backend and defaults to "local".
+func NewStorage(name, scope string) (Storage, error) {
+	switch name {
+	case "", StorageJSON:
+		return JSONStorage{}, nil
+	case StorageGitConfig:
+		if scope == "" {
+			scope = "local"
+		}
+		switch scope {
+		case "local", "worktree", "global":
+			return GitConfigStorage{Scope: scope}, nil
+		}
+		return nil, fmt.Errorf("unknown git config scope %q (expected local, worktree or global)", scope)
+	}
+	return nil, fmt.Errorf("unknown storage %q (expected %s or %s)", name, StorageJSON, StorageGitConfig)
+}
+
+// JSONStorage keeps pairs in pairs.json in the repository's gh-pair
+// directory, and branch pairs in branches/<branch>.json alongside it.
+type JSONStorage struct{}
+
+// branchesDir is the directory holding branch pairs for the JSON backend.
+const branchesDir = "branches"
+
+// jsonFileName returns the file holding a set of pairs.
+func jsonFileName(branch string) string {
+	if branch == "" {
+		return PairsFileName
+	}
+	return filepath.Join(branchesDir, filepath.FromSlash(branch)+".json")
+}
+
+func (JSONStorage) Name() string { return StorageJSON }
+
+func (JSONStorage) Load(branch string) (*PairsConfig, bool, error) {
+	var config PairsConfig
+	found, err := loadJSON(jsonFileName(branch), &config)
+	if err != nil {
+		return nil, false, err
+	}
+	return &config, found, nil
+}
+
+func (JSONStorage) Save(branch string, config *PairsConfig) error {
+	return saveJSON(jsonFileName(branch), config)
+}
+
+func (JSONStorage) Delete(branch string) error {
+	return removeFile(jsonFileName(branch))
+}
+
+func (JSONStorage) Branches() ([]string, error) {
+	configDir, err := git.ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	root := filepath.Join(configDir, branchesDir)
+	var branches []string
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		branches = append(branches, filepath.ToSlash(strings.TrimSuffix(rel, ".json")))
+		return nil
+	})
+
+	return branches, err
+}
+
+// GitConfigStorage keeps pairs in git config so other tools can read them.
+// Each pair is a "Name <email>" value of the multi-valued pair.coauthor key,
+// with the GitHub username at the same position in pair.username. Branch
+// pairs use the same keys in a subsection, e.g. pair.<branch>.coauthor,
+// and pair.<branch>.scoped marks that the branch has its own set.
+type GitConfigStorage struct {
+	Scope string // "local", "worktree" or "global"
+}
+
+// gitConfigKey returns the key for a set of pairs, e.g. pair.<branch>.coauthor.
+func gitConfigKey(branch, name string) string {
+	if branch == "" {
+		return "pair." + name
+	}
+	return "pair." + branch + "." + name
+}
+
+func (s GitConfigStorage) Name() string { return StorageGitConfig + " (" + s.Scope + ")" }
+
+func (s GitConfigStorage) Load(branch string) (*PairsConfig, bool, error) {
+	coAuthors, err := git.ConfigGetAll(s.Scope, gitConfigKey(branch, "coauthor"))
+	if err != nil {
+		return nil, false, err
+	}
+	usernames, err := git.ConfigGetAll(s.Scope, gitConfigKey(branch, "username"))
+	if err != nil {
+		return nil, false, err
+	}
+
+	found := len(coAuthors) > 0
+	if branch != "" {
+		scoped, err := git.ConfigGetAll(s.Scope, gitConfigKey(branch, "scoped"))
+		if err != nil {
+			return nil, false, err
+		}
+		found = len(scoped) > 0
+	}
+
+	// Usernames only line up if nothing else has edited the coauthor list
+	if len(usernames) != len(coAuthors) {
+		usernames = nil
+	}
+
+	config := &PairsConfig{Pairs: make([]Pair, 0, len(coAuthors))}
+	for i, value := range coAuthors {
+		// Values are the trailer's value, so parse them as a trailer
+		pair, ok := ParseCoAuthorLine(coAuthorKey + ": " + value)
+		if !ok {
+			continue
+		}
+		if usernames != nil {
+			pair.Username = usernames[i]
+		} else {
+			pair.Username = UsernameFromEmail(pair.Email)
+		}
+		config.Pairs = append(config.Pairs, pair)
+	}
+
+	return config, found, nil
+}
+
+func (s GitConfigStorage) Save(branch string, config *PairsConfig) error {
+	coAuthors := make([]string, len(config.Pairs))
+	usernames := make([]string, len(config.Pairs))
+	for i, p := range config.Pairs {
+		coAuthors[i] = p.Name + " <" + p.Email + ">"
+		usernames[i] = p.Username
+	}
+
+	if branch != "" {
+		if err := git.ConfigReplaceAll(s.Scope, gitConfigKey(branch, "scoped"), []string{"true"}); err != nil {
+			return err
+		}
+	}
+	if err := git.ConfigReplaceAll(s.Scope, gitConfigKey(branch, "coauthor"), coAuthors); err != nil {
+		return err
+	}
+	return git.ConfigReplaceAll(s.Scope, gitConfigKey(branch, "username"), usernames)
+}
+
+func (s GitConfigStorage) Delete(branch string) error {
+	for _, name := range []string{"scoped", "coauthor", "username"} {
+		if err := git.ConfigReplaceAll(s.Scope, gitConfigKey(branch, name), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s GitConfigStorage) Branches() ([]string, error) {
+	keys, err := git.ConfigGetRegexp(s.Scope, `^pair\..*\.scoped$`)
+	if err != nil {
+		return nil, err
+	}
+
+	branches := make([]string, 0, len(keys))
+	for _, key := range keys {
+		branch := strings.TrimSuffix(strings.TrimPrefix(key, "pair."), ".scoped")
+		branches = append(branches, branch)
+	}
+	return branches, nil
+}
+
+// UsernameFromEmail guesses a username from an email address, using the
+// GitHub login for noreply addresses and the local part otherwise.
+func UsernameFromEmail(email string) string {
+	local, _, _ := strings.Cut(email, "@")
+	if _, login, ok := strings.Cut(local, "+"); ok && strings.HasSuffix(email, "@users.noreply.github.com") {
+		return login
+	}
+	return local
+}
+
+// SwitchStorage moves the current repository's pairs, including branch
+// pairs, to a new backend and clears the old one, so the commit hook never
+// reads stale pairs. It then records the backend in the repository's config
+// and selects it for the rest of the process. Other repositories keep
+// their own backend.
+func SwitchStorage(to Storage) error {
+	return withLock(func() error {
+		from := storage
+		if from.Name() == to.Name() {
+			return nil
+		}
+
+		branches, err := from.Branches()
+		if err != nil {
+			return err
+		}
+
+		// Undoing the switch brings back the pairs that applied before it
+		entries, err := snapshotAll(from, "switch storage to "+to.Name())
+		if err != nil {
+			return err
+		}
+		existing, err := to.Branches()
+		if err != nil {
+			return err
+		}
+		journal, err := LoadJournal()
+		if err != nil {
+			return err
+		}
+		journal.add(removeOthers(entries, existing))
+		if err := SaveJournal(journal); err != nil {
+			return err
+		}
+
+		for _, branch := range append([]string{""}, branches...) {
+			config, _, err := from.Load(branch)
+			if err != nil {
+				return err
+			}
+			if config.Pairs == nil {
+				config.Pairs = []Pair{}
+			}
+
+			if err := to.Save(branch, config); err != nil {
+				return err
+			}
+		}
+
+		if err := from.Save("", &PairsConfig{Pairs: []Pair{}}); err != nil {
+			return err
+		}
+		for _, branch := range branches {
+			if err := from.Delete(branch); err != nil {
+				return err
+			}
+		}
+
+		if err := setRepoStorage(to); err != nil {
+			return err
+		}
+		storage = to
+		return touch()
+	})
+}
@@ -0,0 +1,63 @@
+// Package fileutil writes and locks the files shared between gh-pair
+// processes and the git hooks.
+package fileutil
+
+import (
+	"os"
+	"path/filepath"
+)
+
+const lockFileName = ".lock"
+
+// WithLock runs fn while holding an exclusive advisory lock on dir, so
+// read-modify-write operations from concurrent gh-pair processes don't
+// overwrite each other. The lock is not reentrant: fn must not take it
+// again.
+func WithLock(dir string, fn func() error) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, lockFileName), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := lockFile(f); err != nil {
+		return err
+	}
+	defer unlockFile(f)
+
+	return fn()
+}
+
+// WriteAtomic writes data to a temporary file and renames it into place,
+// so readers such as the commit hook never see a partially written file.
+func WriteAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	// Clean up the temporary file if anything goes wrong
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
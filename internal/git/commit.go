@@ -0,0 +1,252 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Commit holds the parts of a commit needed to recreate it.
+type Commit struct {
+	Hash        string
+	Parents     []string
+	Tree        string
+	AuthorName  string
+	AuthorEmail string
+	AuthorDate  string // raw format: "<unix seconds> <tz offset>"
+	Message     string
+}
+
+// ReadCommit reads the metadata and message of a single commit.
+func ReadCommit(rev string) (*Commit, error) {
+	commits, err := Log("-1", rev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit %s: %w", rev, err)
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("unknown revision: %s", rev)
+	}
+	return &commits[0], nil
+}
+
+// RawMessage returns a commit's message exactly as stored, unlike Log,
+// which normalises trailing newlines.
+func RawMessage(rev string) (string, error) {
+	cmd := exec.Command("git", "cat-file", "commit", rev)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read commit %s: %w", rev, err)
+	}
+	_, message, _ := strings.Cut(string(output), "\n\n")
+	return message, nil
+}
+
+// InterpretTrailers adds trailers to a commit message with git
+// interpret-trailers, which places them in the message's trailer block.
+// ifExists is the --if-exists action for trailers already present.
+func InterpretTrailers(message, ifExists string, trailers []string) (string, error) {
+	args := []string{"interpret-trailers", "--if-exists", ifExists}
+	for _, t := range trailers {
+		args = append(args, "--trailer", t)
+	}
+
+	cmd := exec.Command("git", args...)
+	cmd.Stdin = strings.NewReader(message)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("failed to add trailers: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", err
+	}
+	return string(output), nil
+}
+
+// RevList returns the commit hashes selected by the given rev-list arguments.
+func RevList(args ...string) ([]string, error) {
+	cmd := exec.Command("git", append([]string{"rev-list"}, args...)...)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("invalid revision range: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// ResolveRev resolves a revision to its full commit hash.
+func ResolveRev(rev string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("unknown revision: %s", rev)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Upstream returns the upstream branch of the current branch, or an empty
+// string if none is configured.
+func Upstream() string {
+	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// IsAncestor reports whether commit is reachable from rev.
+func IsAncestor(commit, rev string) bool {
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", commit, rev)
+	return cmd.Run() == nil
+}
+
+// CommitTree creates a new commit with the tree and author of c, the given
+// parents and message, and returns its hash. The committer is the current user.
+func CommitTree(c *Commit, parents []string, message string) (string, error) {
+	args := []string{"commit-tree", c.Tree}
+	for _, p := range parents {
+		args = append(args, "-p", p)
+	}
+
+	cmd := exec.Command("git", args...)
+	cmd.Stdin = strings.NewReader(message)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME="+c.AuthorName,
+		"GIT_AUTHOR_EMAIL="+c.AuthorEmail,
+		"GIT_AUTHOR_DATE="+c.AuthorDate,
+	)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("failed to create commit: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// UpdateRef points ref at newValue, provided it still points at oldValue.
+func UpdateRef(ref, newValue, oldValue, reason string) error {
+	cmd := exec.Command("git", "update-ref", "-m", reason, ref, newValue, oldValue)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to update %s: %s", ref, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// RewriteMessages rewrites the given commits on the current branch, passing
+// each of their messages through rewrite, and replays any descendants up to
+// HEAD on top of them with their messages unchanged. Trees are untouched, so
+// the working tree and index are unaffected. It returns the number of
+// commits whose message changed.
+func RewriteMessages(targets []string, rewrite func(string) (string, error)) (int, error) {
+	head, err := ResolveRev("HEAD")
+	if err != nil {
+		return 0, err
+	}
+
+	isTarget := make(map[string]bool, len(targets))
+	for _, t := range targets {
+		if !IsAncestor(t, head) {
+			return 0, fmt.Errorf("commit %s is not on the current branch", short(t))
+		}
+		isTarget[t] = true
+	}
+
+	// Walk everything between the targets' parents and HEAD, oldest first,
+	// so each commit is rewritten after its parents.
+	args := []string{"--reverse", "--topo-order", head}
+	for _, t := range targets {
+		c, err := ReadCommit(t)
+		if err != nil {
+			return 0, err
+		}
+		for _, p := range c.Parents {
+			if !isTarget[p] {
+				args = append(args, "^"+p)
+			}
+		}
+	}
+
+	chain, err := RevList(args...)
+	if err != nil {
+		return 0, err
+	}
+
+	seen := 0
+	for _, hash := range chain {
+		if isTarget[hash] {
+			seen++
+		}
+	}
+	if seen != len(isTarget) {
+		return 0, fmt.Errorf("commits in range are reachable from outside it; rewrite them from a linear range instead")
+	}
+
+	rewritten := make(map[string]string)
+	changed := 0
+	newHead := head
+
+	for _, hash := range chain {
+		c, err := ReadCommit(hash)
+		if err != nil {
+			return 0, err
+		}
+
+		parents := make([]string, len(c.Parents))
+		parentsChanged := false
+		for i, p := range c.Parents {
+			parents[i] = p
+			if np, ok := rewritten[p]; ok {
+				parents[i] = np
+				parentsChanged = true
+			}
+		}
+
+		original, err := RawMessage(hash)
+		if err != nil {
+			return 0, err
+		}
+		message := original
+		if isTarget[hash] {
+			if message, err = rewrite(original); err != nil {
+				return 0, err
+			}
+		}
+
+		if message == original && !parentsChanged {
+			newHead = hash
+			continue
+		}
+		if message != original {
+			changed++
+		}
+
+		newHash, err := CommitTree(c, parents, message)
+		if err != nil {
+			return 0, err
+		}
+		rewritten[hash] = newHash
+		newHead = newHash
+	}
+
+	if changed == 0 {
+		return 0, nil
+	}
+
+	if err := UpdateRef("HEAD", newHead, head, "gh-pair: amend co-authors"); err != nil {
+		return 0, err
+	}
+	return changed, nil
+}
+
+// short abbreviates a commit hash for display.
+func short(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
@@ -0,0 +1,173 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ConfigGet returns the effective value of a git config key, or an empty
+// string if it is not set.
+func ConfigGet(key string) string {
+	cmd := exec.Command("git", "config", "--get", key)
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// ConfigGetLocal returns a value from the repository's own config file,
+// ignoring global and system config. ok is false if the key is not set there.
+func ConfigGetLocal(key string) (value string, ok bool) {
+	cmd := exec.Command("git", "config", "--local", "--get", key)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", false
+	}
+	return strings.TrimSpace(string(output)), true
+}
+
+// ConfigSetLocal sets a key in the repository's own config file.
+func ConfigSetLocal(key, value string) error {
+	cmd := exec.Command("git", "config", "--local", key, value)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to set %s: %s", key, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// ConfigUnsetLocal removes a key from the repository's own config file.
+// It is not an error if the key is not set.
+func ConfigUnsetLocal(key string) error {
+	cmd := exec.Command("git", "config", "--local", "--unset-all", key)
+	if err := cmd.Run(); err != nil {
+		// Exit code 5 means the key was not set
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 5 {
+			return nil
+		}
+		return fmt.Errorf("failed to unset %s: %w", key, err)
+	}
+	return nil
+}
+
+// Identity is the author identity set in the repository's own git config.
+// The Has fields record whether each value was set, so it can be restored
+// exactly, including removing values that were not there before.
+type Identity struct {
+	Name     string `json:"name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	HasName  bool   `json:"hasName"`
+	HasEmail bool   `json:"hasEmail"`
+}
+
+// LocalIdentity reads the author identity from the repository's git config.
+func LocalIdentity() Identity {
+	var id Identity
+	id.Name, id.HasName = ConfigGetLocal("user.name")
+	id.Email, id.HasEmail = ConfigGetLocal("user.email")
+	return id
+}
+
+// Restore writes the identity back to the repository's git config.
+func (id Identity) Restore() error {
+	if id.HasName {
+		if err := ConfigSetLocal("user.name", id.Name); err != nil {
+			return err
+		}
+	} else if err := ConfigUnsetLocal("user.name"); err != nil {
+		return err
+	}
+
+	if id.HasEmail {
+		return ConfigSetLocal("user.email", id.Email)
+	}
+	return ConfigUnsetLocal("user.email")
+}
+
+// CommitEmail returns the email commits are made with under this identity:
+// its own, or the global one if the repository didn't set one.
+func (id Identity) CommitEmail() string {
+	if id.HasEmail {
+		return id.Email
+	}
+	values, err := ConfigGetAll("global", "user.email")
+	if err != nil || len(values) == 0 {
+		return ""
+	}
+	return values[len(values)-1]
+}
+
+// SetLocalIdentity makes name and email the author of new commits in this
+// repository, overriding any global identity.
+func SetLocalIdentity(name, email string) error {
+	if err := ConfigSetLocal("user.name", name); err != nil {
+		return err
+	}
+	return ConfigSetLocal("user.email", email)
+}
+
+// ConfigGetAll returns every value of a multi-valued key in the given
+// scope ("local", "worktree" or "global").
+func ConfigGetAll(scope, key string) ([]string, error) {
+	cmd := exec.Command("git", "config", "--"+scope, "--get-all", key)
+	output, err := cmd.Output()
+	if err != nil {
+		// Exit code 1 means the key is not set
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return nil, nil
+		}
+		return nil, configError(key, err)
+	}
+
+	var values []string
+	for _, line := range strings.Split(strings.TrimRight(string(output), "\n"), "\n") {
+		if line != "" {
+			values = append(values, line)
+		}
+	}
+	return values, nil
+}
+
+// ConfigReplaceAll replaces every value of a multi-valued key in the given
+// scope with values, removing the key if values is empty.
+func ConfigReplaceAll(scope, key string, values []string) error {
+	cmd := exec.Command("git", "config", "--"+scope, "--unset-all", key)
+	if _, err := cmd.Output(); err != nil {
+		// Exit code 5 means the key was not set
+		if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 5 {
+			return configError(key, err)
+		}
+	}
+
+	for _, value := range values {
+		cmd := exec.Command("git", "config", "--"+scope, "--add", key, value)
+		if _, err := cmd.Output(); err != nil {
+			return configError(key, err)
+		}
+	}
+	return nil
+}
+
+// configError adds git's own explanation to a failed git config command.
+func configError(key string, err error) error {
+	if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("git config %s: %s", key, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return fmt.Errorf("git config %s: %w", key, err)
+}
+
+// ConfigGetRegexp returns the names of keys in the given scope that match
+// the regular expression.
+func ConfigGetRegexp(scope, pattern string) ([]string, error) {
+	cmd := exec.Command("git", "config", "--"+scope, "--name-only", "--get-regexp", pattern)
+	output, err := cmd.Output()
+	if err != nil {
+		// Exit code 1 means no keys matched
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return nil, nil
+		}
+		return nil, configError(pattern, err)
+	}
+	return strings.Fields(string(output)), nil
+}
@@ -0,0 +1,62 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Log returns the commits selected by the given git log arguments, newest first.
+func Log(args ...string) ([]Commit, error) {
+	args = append([]string{"log", "--date=raw",
+		"--format=%H%x00%P%x00%T%x00%an%x00%ae%x00%ad%x00%B%x1e"}, args...)
+	cmd := exec.Command("git", args...)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("git log failed: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, err
+	}
+
+	var commits []Commit
+	for _, record := range strings.Split(string(output), "\x1e") {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+
+		fields := strings.SplitN(record, "\x00", 7)
+		if len(fields) != 7 {
+			continue
+		}
+
+		commits = append(commits, Commit{
+			Hash:        fields[0],
+			Parents:     strings.Fields(fields[1]),
+			Tree:        fields[2],
+			AuthorName:  fields[3],
+			AuthorEmail: fields[4],
+			AuthorDate:  fields[5],
+			Message:     strings.TrimRight(fields[6], "\n") + "\n",
+		})
+	}
+
+	return commits, nil
+}
+
+// Time returns the author date of the commit.
+func (c Commit) Time() time.Time {
+	fields := strings.Fields(c.AuthorDate)
+	if len(fields) == 0 {
+		return time.Time{}
+	}
+
+	seconds, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0)
+}
@@ -2,6 +2,7 @@ package git
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -12,6 +13,15 @@ var (
 	ErrNotARepository = errors.New("not a git repository")
 )
 
+// Pair scopes, choosing which git directory holds gh-pair's files.
+const (
+	ScopeShared   = "shared"   // one set of pairs for all worktrees
+	ScopeWorktree = "worktree" // separate pairs for each worktree
+)
+
+// scopeConfigKey is the git config key holding the pair scope.
+const scopeConfigKey = "gh-pair.scope"
+
 // RepoRoot returns the root directory of the current git repository.
 func RepoRoot() (string, error) {
 	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
@@ -23,42 +33,86 @@ func RepoRoot() (string, error) {
 }
 
 // GitDir returns the .git directory path for the current repository.
+// In a linked worktree this is the worktree's own git directory.
 func GitDir() (string, error) {
-	cmd := exec.Command("git", "rev-parse", "--git-dir")
+	return revParsePath("--git-dir")
+}
+
+// CommonDir returns the git directory shared by all worktrees of the
+// current repository.
+func CommonDir() (string, error) {
+	return revParsePath("--git-common-dir")
+}
+
+// revParsePath runs git rev-parse with a path option and returns the
+// result as an absolute path.
+func revParsePath(option string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", option)
 	output, err := cmd.Output()
 	if err != nil {
 		return "", ErrNotARepository
 	}
-	gitDir := strings.TrimSpace(string(output))
+	path := strings.TrimSpace(string(output))
 
 	// Convert relative path to absolute
-	if !filepath.IsAbs(gitDir) {
+	if !filepath.IsAbs(path) {
 		cwd, err := os.Getwd()
 		if err != nil {
 			return "", err
 		}
-		gitDir = filepath.Join(cwd, gitDir)
+		path = filepath.Join(cwd, path)
 	}
 
-	return gitDir, nil
+	return path, nil
+}
+
+// PairScope returns whether pairs are shared by all worktrees of the
+// repository (the default) or kept separately for each worktree.
+func PairScope() string {
+	if ConfigGet(scopeConfigKey) == ScopeWorktree {
+		return ScopeWorktree
+	}
+	return ScopeShared
+}
+
+// HasPairScope reports whether a pair scope has been chosen for the
+// repository.
+func HasPairScope() bool {
+	return ConfigGet(scopeConfigKey) != ""
+}
+
+// SetPairScope records the pair scope in the repository's config, which
+// all worktrees share.
+func SetPairScope(scope string) error {
+	return ConfigSetLocal(scopeConfigKey, scope)
 }
 
-// HooksDir returns the path to the hooks directory.
+// HooksDir returns the path to the hooks directory, which git shares
+// between all worktrees.
 func HooksDir() (string, error) {
-	gitDir, err := GitDir()
+	commonDir, err := CommonDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(gitDir, "hooks"), nil
+	return filepath.Join(commonDir, "hooks"), nil
 }
 
 // ConfigDir returns the path to the gh-pair config directory within .git.
+// It is in the common git directory unless pairs are scoped per worktree.
 func ConfigDir() (string, error) {
-	gitDir, err := GitDir()
+	return ConfigDirForScope(PairScope())
+}
+
+// ConfigDirForScope returns the gh-pair config directory used for a scope.
+func ConfigDirForScope(scope string) (string, error) {
+	dir, err := CommonDir()
+	if scope == ScopeWorktree {
+		dir, err = GitDir()
+	}
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(gitDir, "gh-pair"), nil
+	return filepath.Join(dir, "gh-pair"), nil
 }
 
 // EnsureConfigDir creates the gh-pair config directory if it doesn't exist.
@@ -73,6 +127,28 @@ func EnsureConfigDir() (string, error) {
 	return configDir, nil
 }
 
+// CheckBranchName returns an error if name isn't a valid branch name.
+func CheckBranchName(name string) error {
+	cmd := exec.Command("git", "check-ref-format", "--branch", name)
+	output, err := cmd.Output()
+	// --branch expands names like @{-1}, which aren't literal branch names
+	if err != nil || strings.TrimSpace(string(output)) != name {
+		return fmt.Errorf("invalid branch name %q", name)
+	}
+	return nil
+}
+
+// CurrentBranch returns the short name of the checked out branch, or an
+// empty string if HEAD is detached.
+func CurrentBranch() string {
+	cmd := exec.Command("git", "symbolic-ref", "--short", "-q", "HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
 // IsInsideWorkTree checks if we're inside a git work tree.
 func IsInsideWorkTree() bool {
 	cmd := exec.Command("git", "rev-parse", "--is-inside-work-tree")
@@ -2,6 +2,7 @@ package github
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -22,6 +23,9 @@ type searchResponse struct {
 	Items []userResponse `json:"items"`
 }
 
+// ErrUserNotFound is returned when GitHub has no user with the given login.
+var ErrUserNotFound = errors.New("user not found")
+
 // LookupUser fetches a GitHub user by username and returns a Pair.
 func LookupUser(username string) (*config.Pair, error) {
 	// Strip @ prefix if present
@@ -31,7 +35,11 @@ func LookupUser(username string) (*config.Pair, error) {
 	output, err := cmd.Output()
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
-			return nil, fmt.Errorf("user not found: %s (gh api error: %s)", username, string(exitErr.Stderr))
+			stderr := string(exitErr.Stderr)
+			if strings.Contains(stderr, "HTTP 404") {
+				return nil, fmt.Errorf("%w: %s", ErrUserNotFound, username)
+			}
+			return nil, fmt.Errorf("failed to lookup user %s (gh api error: %s)", username, stderr)
 		}
 		return nil, fmt.Errorf("failed to lookup user: %w", err)
 	}
@@ -53,11 +61,13 @@ func LookupUser(username string) (*config.Pair, error) {
 		name = user.Login
 	}
 
-	return &config.Pair{
+	// Prefer the name and email the user chose with 'gh pair edit'
+	pair := config.ApplyRoster(config.Pair{
 		Username: user.Login,
 		Name:     name,
 		Email:    email,
-	}, nil
+	})
+	return &pair, nil
 }
 
 // SearchUsers searches for GitHub users matching the query.
@@ -231,3 +241,37 @@ Email:    email,
 
 return pairs, nil
 }
+
+// GetTeammates fetches the members of every team the authenticated user
+// belongs to, or of a single team given as "org/slug".
+func GetTeammates(team string) ([]config.Pair, error) {
+	if team != "" {
+		org, slug, ok := strings.Cut(team, "/")
+		if !ok || org == "" || slug == "" {
+			return nil, fmt.Errorf("invalid team %q: expected org/slug", team)
+		}
+		return GetTeamMembers(org, slug)
+	}
+
+	teams, err := GetUserTeams()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var members []config.Pair
+	for _, t := range teams {
+		teamMembers, err := GetTeamMembers(t.Org, t.Slug)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range teamMembers {
+			if !seen[p.Username] {
+				seen[p.Username] = true
+				members = append(members, p)
+			}
+		}
+	}
+
+	return members, nil
+}
@@ -0,0 +1,86 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// CommitState records whether a user has authored commits in a repository.
+type CommitState int
+
+const (
+	CommitsUnknown CommitState = iota // the repository couldn't be checked
+	CommitsFound
+	CommitsNone
+)
+
+// Profile holds the public details that help tell users apart.
+type Profile struct {
+	Username  string
+	Name      string
+	Company   string
+	Location  string
+	Bio       string
+	Orgs      []string    // public organization memberships
+	Committed CommitState // commits in the current repository
+}
+
+// GetUserProfile fetches a user's public profile, their public organizations
+// and whether they have committed to the current repository. Only the
+// profile itself is required; the rest is left empty if it can't be fetched.
+func GetUserProfile(username string) (*Profile, error) {
+	username = strings.TrimPrefix(username, "@")
+
+	output, err := exec.Command("gh", "api", fmt.Sprintf("users/%s", username)).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch profile for %s: %w", username, err)
+	}
+
+	var user struct {
+		Login    string `json:"login"`
+		Name     string `json:"name"`
+		Company  string `json:"company"`
+		Location string `json:"location"`
+		Bio      string `json:"bio"`
+	}
+	if err := json.Unmarshal(output, &user); err != nil {
+		return nil, fmt.Errorf("failed to parse profile: %w", err)
+	}
+
+	profile := &Profile{
+		Username: user.Login,
+		Name:     user.Name,
+		Company:  user.Company,
+		Location: user.Location,
+		Bio:      strings.TrimSpace(user.Bio),
+	}
+
+	// Public organizations
+	output, err = exec.Command("gh", "api", fmt.Sprintf("users/%s/orgs", username)).Output()
+	if err == nil {
+		var orgs []struct {
+			Login string `json:"login"`
+		}
+		if json.Unmarshal(output, &orgs) == nil {
+			for _, o := range orgs {
+				profile.Orgs = append(profile.Orgs, o.Login)
+			}
+		}
+	}
+
+	// Commits in this repository; gh fills in {owner}/{repo}
+	output, err = exec.Command("gh", "api", fmt.Sprintf("repos/{owner}/{repo}/commits?author=%s&per_page=1", username)).Output()
+	if err == nil {
+		var commits []json.RawMessage
+		if json.Unmarshal(output, &commits) == nil {
+			profile.Committed = CommitsNone
+			if len(commits) > 0 {
+				profile.Committed = CommitsFound
+			}
+		}
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,244 @@
+// Package history reads pairing history from Co-Authored-By trailers in git log.
+package history
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+	"github.com/omgitsads/gh-pair/internal/git"
+)
+
+// Person is a commit author or co-author, identified by email.
+type Person struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// Key returns the identity used to match a person across commits.
+func (p Person) Key() string {
+	return strings.ToLower(p.Email)
+}
+
+// Commit is a commit along with everyone credited on it.
+type Commit struct {
+	Hash      string
+	Time      time.Time
+	Author    Person
+	CoAuthors []Person
+}
+
+// Participants returns the author followed by the co-authors, without duplicates.
+func (c Commit) Participants() []Person {
+	seen := map[string]bool{c.Author.Key(): true}
+	people := []Person{c.Author}
+	for _, p := range c.CoAuthors {
+		if !seen[p.Key()] {
+			seen[p.Key()] = true
+			people = append(people, p)
+		}
+	}
+	return people
+}
+
+// Options filters which commits are read.
+type Options struct {
+	Since string   // passed to git log --since, e.g. "2 weeks ago"
+	Until string   // passed to git log --until
+	Revs  []string // revisions to walk; defaults to HEAD
+}
+
+// Load reads commits from git log that have at least one co-author.
+func Load(opts Options) ([]Commit, error) {
+	var args []string
+	if opts.Since != "" {
+		args = append(args, "--since="+opts.Since)
+	}
+	if opts.Until != "" {
+		args = append(args, "--until="+opts.Until)
+	}
+	args = append(args, opts.Revs...)
+
+	logged, err := git.Log(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []Commit
+	for _, c := range logged {
+		coAuthors := ParseCoAuthors(c.Message)
+		if len(coAuthors) == 0 {
+			continue
+		}
+		commits = append(commits, Commit{
+			Hash:      c.Hash,
+			Time:      c.Time(),
+			Author:    Person{Name: c.AuthorName, Email: c.AuthorEmail},
+			CoAuthors: coAuthors,
+		})
+	}
+
+	return commits, nil
+}
+
+// ParseCoAuthors extracts the people named in Co-Authored-By trailers.
+func ParseCoAuthors(message string) []Person {
+	var people []Person
+	for _, line := range strings.Split(message, "\n") {
+		if p, ok := config.ParseCoAuthorLine(line); ok {
+			people = append(people, Person{Name: p.Name, Email: p.Email})
+		}
+	}
+	return people
+}
+
+// Pairing counts the commits two people made together.
+type Pairing struct {
+	People  [2]Person `json:"people"`
+	Commits int       `json:"commits"`
+	First   time.Time `json:"first"`
+	Last    time.Time `json:"last"`
+}
+
+// AuthorStats summarises one person's pairing activity.
+type AuthorStats struct {
+	Person
+	Commits  int       `json:"commits"`
+	Partners int       `json:"partners"`
+	Last     time.Time `json:"lastPaired"`
+}
+
+// Report is the aggregated pairing history.
+type Report struct {
+	Commits  int           `json:"commits"`
+	Pairings []Pairing     `json:"pairings"`
+	Authors  []AuthorStats `json:"authors"`
+}
+
+// Summarize aggregates commits into pairing and per-author statistics.
+// If person is non-empty, only commits they took part in are counted; it is
+// matched as described by Person.Matches.
+func Summarize(commits []Commit, person string) Report {
+	names := make(map[string]Person)
+	pairings := make(map[[2]string]*Pairing)
+	authors := make(map[string]*AuthorStats)
+	partners := make(map[string]map[string]bool)
+	report := Report{Pairings: []Pairing{}, Authors: []AuthorStats{}}
+
+	for _, c := range commits {
+		people := c.Participants()
+		if person != "" && !involves(people, person) {
+			continue
+		}
+		report.Commits++
+
+		for _, p := range people {
+			// Commits are newest first, so the first name seen is the latest
+			if _, ok := names[p.Key()]; !ok {
+				names[p.Key()] = p
+			}
+
+			a, ok := authors[p.Key()]
+			if !ok {
+				a = &AuthorStats{}
+				authors[p.Key()] = a
+				partners[p.Key()] = make(map[string]bool)
+			}
+			a.Commits++
+			if c.Time.After(a.Last) {
+				a.Last = c.Time
+			}
+		}
+
+		for i := 0; i < len(people); i++ {
+			for j := i + 1; j < len(people); j++ {
+				a, b := people[i].Key(), people[j].Key()
+				if a > b {
+					a, b = b, a
+				}
+				partners[a][b] = true
+				partners[b][a] = true
+
+				key := [2]string{a, b}
+				pr, ok := pairings[key]
+				if !ok {
+					pr = &Pairing{First: c.Time, Last: c.Time}
+					pairings[key] = pr
+				}
+				pr.Commits++
+				if c.Time.Before(pr.First) {
+					pr.First = c.Time
+				}
+				if c.Time.After(pr.Last) {
+					pr.Last = c.Time
+				}
+			}
+		}
+	}
+
+	for key, pr := range pairings {
+		pr.People = [2]Person{names[key[0]], names[key[1]]}
+		report.Pairings = append(report.Pairings, *pr)
+	}
+	sort.Slice(report.Pairings, func(i, j int) bool {
+		a, b := report.Pairings[i], report.Pairings[j]
+		if a.Commits != b.Commits {
+			return a.Commits > b.Commits
+		}
+		return a.Last.After(b.Last)
+	})
+
+	for key, a := range authors {
+		a.Person = names[key]
+		a.Partners = len(partners[key])
+		report.Authors = append(report.Authors, *a)
+	}
+	sort.Slice(report.Authors, func(i, j int) bool {
+		a, b := report.Authors[i], report.Authors[j]
+		if a.Commits != b.Commits {
+			return a.Commits > b.Commits
+		}
+		return a.Name < b.Name
+	})
+
+	return report
+}
+
+// involves reports whether person matches any of the given people.
+func involves(people []Person, person string) bool {
+	for _, p := range people {
+		if p.Matches(person) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches reports whether query identifies this person by email, name, or
+// GitHub username (via a noreply email), case-insensitively.
+func (p Person) Matches(query string) bool {
+	query = strings.ToLower(strings.TrimPrefix(query, "@"))
+	if query == "" {
+		return false
+	}
+	return p.Key() == query ||
+		strings.ToLower(p.Name) == query ||
+		Login(p.Email) == query
+}
+
+// Login returns the GitHub username encoded in a noreply email address,
+// or an empty string for any other address.
+func Login(email string) string {
+	const domain = "@users.noreply.github.com"
+	email = strings.ToLower(email)
+	if !strings.HasSuffix(email, domain) {
+		return ""
+	}
+
+	local := strings.TrimSuffix(email, domain)
+	if i := strings.Index(local, "+"); i != -1 {
+		local = local[i+1:]
+	}
+	return local
+}
@@ -0,0 +1,127 @@
+package history
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+)
+
+// Suggestion is a potential pair ranked by how long ago you last paired.
+type Suggestion struct {
+	Pair       config.Pair `json:"pair"`
+	LastPaired time.Time   `json:"lastPaired"` // zero if never paired
+	Commits    int         `json:"commits"`
+}
+
+// Suggest ranks candidates by how recently they paired with me, least
+// recent first; people never paired with come first. me lists the
+// identifiers (emails, names, or usernames) that refer to the current user.
+func Suggest(commits []Commit, me []string, candidates []config.Pair) []Suggestion {
+	suggestions := make([]Suggestion, len(candidates))
+	for i, c := range candidates {
+		suggestions[i] = Suggestion{Pair: c}
+	}
+
+	for _, c := range commits {
+		people := c.Participants()
+		if !includesAny(people, me) {
+			continue
+		}
+
+		for i := range suggestions {
+			s := &suggestions[i]
+			if !includesPair(people, s.Pair) {
+				continue
+			}
+			s.Commits++
+			if c.Time.After(s.LastPaired) {
+				s.LastPaired = c.Time
+			}
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.LastPaired.Equal(b.LastPaired) {
+			return strings.ToLower(a.Pair.Username) < strings.ToLower(b.Pair.Username)
+		}
+		return a.LastPaired.Before(b.LastPaired)
+	})
+
+	return suggestions
+}
+
+// includesAny reports whether any of the identifiers matches one of the people.
+func includesAny(people []Person, ids []string) bool {
+	for _, id := range ids {
+		if involves(people, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// includesPair reports whether the pair is one of the people.
+func includesPair(people []Person, pair config.Pair) bool {
+	for _, p := range people {
+		if p.Matches(pair.Username) || p.Matches(pair.Email) {
+			return true
+		}
+		if pair.Name != pair.Username && p.Matches(pair.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Ago describes how long before now t was, in whole days.
+func Ago(t, now time.Time) string {
+	if t.IsZero() {
+		return "never paired"
+	}
+
+	days := int(now.Sub(t).Hours() / 24)
+	switch {
+	case days <= 0:
+		return "last paired today"
+	case days == 1:
+		return "last paired 1 day ago"
+	default:
+		return fmt.Sprintf("last paired %d days ago", days)
+	}
+}
+
+// SuggestFromRoster loads history from all branches and ranks the roster
+// members for the current user, identified by their GitHub username and
+// git identity. Members are matched using the fuller details in the recent
+// list where available, since team rosters only carry noreply emails.
+func SuggestFromRoster(roster []config.Pair, currentUser string) ([]Suggestion, error) {
+	commits, err := Load(Options{Revs: []string{"--all"}})
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]config.Pair)
+	if recent, err := config.LoadRecent(); err == nil {
+		for _, p := range recent.Recent {
+			known[strings.ToLower(p.Username)] = p
+		}
+	}
+
+	candidates := make([]config.Pair, 0, len(roster))
+	for _, p := range roster {
+		if strings.EqualFold(p.Username, currentUser) {
+			continue
+		}
+		if k, ok := known[strings.ToLower(p.Username)]; ok {
+			p = k
+		}
+		candidates = append(candidates, p)
+	}
+
+	me := []string{currentUser, config.OwnerEmail()}
+	return Suggest(commits, me, candidates), nil
+}
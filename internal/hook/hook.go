@@ -3,6 +3,7 @@ package hook
 import (
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/omgitsads/gh-pair/internal/git"
 )
@@ -18,19 +19,17 @@ const hookScript = `#!/bin/sh
 
 COMMIT_MSG_FILE="$1"
 
-# Path to the pairs config file
-GIT_DIR=$(git rev-parse --git-dir 2>/dev/null)
+# Path to the pairs config file, shared by all worktrees unless
+# pairs are scoped per worktree (gh pair scope set worktree)
+if [ "$(git config --get gh-pair.scope)" = "worktree" ]; then
+  GIT_DIR=$(git rev-parse --git-dir 2>/dev/null)
+else
+  GIT_DIR=$(git rev-parse --git-common-dir 2>/dev/null)
+fi
 CONFIG_FILE="$GIT_DIR/gh-pair/pairs.json"
 
-# Check if config file exists
-if [ ! -f "$CONFIG_FILE" ]; then
-  exit 0
-fi
-
-# Check if there are any pairs configured
-if ! grep -q '"username"' "$CONFIG_FILE" 2>/dev/null; then
-  exit 0
-fi
+# Pairs scoped to the current branch take precedence over the default pairs
+BRANCH=$(git symbolic-ref --short -q HEAD 2>/dev/null)
 
 # Get the commit message without comments and whitespace
 MSG_CONTENT=$(grep -v '^#' "$COMMIT_MSG_FILE" | grep -v '^[[:space:]]*$' || true)
@@ -40,43 +39,152 @@ if [ -z "$MSG_CONTENT" ]; then
   exit 0
 fi
 
-# Check if co-authors are already present in the commit message
-if grep -q "^Co-Authored-By:" "$COMMIT_MSG_FILE" 2>/dev/null; then
-  exit 0
+# The repository's config chooses where its pairs are stored
+if [ "$(git config --local --get gh-pair.storage)" = "gitconfig" ]; then
+  # Pairs are stored as "Name <email>" values of pair.coauthor
+  KEY="pair.coauthor"
+  if [ -n "$BRANCH" ] && [ "$(git config --get "pair.$BRANCH.scoped")" = "true" ]; then
+    KEY="pair.$BRANCH.coauthor"
+  fi
+  COAUTHORS=$(git config --get-all "$KEY" 2>/dev/null | sed 's/^/Co-Authored-By: /')
+else
+  if [ -n "$BRANCH" ] && [ -f "$GIT_DIR/gh-pair/branches/$BRANCH.json" ]; then
+    CONFIG_FILE="$GIT_DIR/gh-pair/branches/$BRANCH.json"
+  fi
+
+  # Check if config file exists
+  if [ ! -f "$CONFIG_FILE" ]; then
+    exit 0
+  fi
+
+  # Check if there are any pairs configured
+  if ! grep -q '"username"' "$CONFIG_FILE" 2>/dev/null; then
+    exit 0
+  fi
+
+  # Extract co-author lines from JSON
+  # Uses simple text processing to avoid requiring jq
+  COAUTHORS=$(awk '
+    /"name":/ { 
+      gsub(/.*"name": *"/, ""); 
+      gsub(/".*/, ""); 
+      name = $0 
+    }
+    /"email":/ { 
+      gsub(/.*"email": *"/, ""); 
+      gsub(/".*/, ""); 
+      print "Co-Authored-By: " name " <" $0 ">" 
+    }
+  ' "$CONFIG_FILE")
 fi
 
-# Extract co-author lines from JSON
-# Uses simple text processing to avoid requiring jq
-COAUTHORS=$(awk '
-  /"name":/ { 
-    gsub(/.*"name": *"/, ""); 
-    gsub(/".*/, ""); 
-    name = $0 
-  }
-  /"email":/ { 
-    gsub(/.*"email": *"/, ""); 
-    gsub(/".*/, ""); 
-    print "Co-Authored-By: " name " <" $0 ">" 
-  }
-' "$CONFIG_FILE")
-
 # If no co-authors found, exit
 if [ -z "$COAUTHORS" ]; then
   exit 0
 fi
 
-# Append co-authors to the end of the commit message with a blank line separator
-{
-  echo ""
-  echo "$COAUTHORS"
-} >> "$COMMIT_MSG_FILE"
+# Add the co-authors to the message's trailers, skipping any it already has
+set --
+while IFS= read -r LINE; do
+  set -- "$@" --trailer "$LINE"
+done <<EOF
+$COAUTHORS
+EOF
+git interpret-trailers --in-place --if-exists ` + trailerIfExists + ` "$@" "$COMMIT_MSG_FILE"
 
 exit 0
 `
 
-const hookMarker = "# gh-pair: Adds co-author trailers to commits"
+const postCheckoutScript = `#!/bin/sh
+# gh-pair: Shows which pairs apply after switching branches
+#
+# This hook is managed by gh-pair. Do not edit manually.
+# To update, run: gh pair init
+#
+# Arguments:
+#   $1 - Previous HEAD
+#   $2 - New HEAD
+#   $3 - 1 for a branch checkout, 0 for a file checkout
+
+# Only report when switching branches
+if [ "$3" != "1" ]; then
+  exit 0
+fi
 
-// Install installs the commit-msg hook.
+BRANCH=$(git symbolic-ref --short -q HEAD 2>/dev/null)
+if [ -z "$BRANCH" ]; then
+  exit 0
+fi
+
+if [ "$(git config --get gh-pair.scope)" = "worktree" ]; then
+  GIT_DIR=$(git rev-parse --git-dir 2>/dev/null)
+else
+  GIT_DIR=$(git rev-parse --git-common-dir 2>/dev/null)
+fi
+
+SOURCE="default"
+if [ "$(git config --local --get gh-pair.storage)" = "gitconfig" ]; then
+  KEY="pair.coauthor"
+  if [ "$(git config --get "pair.$BRANCH.scoped")" = "true" ]; then
+    KEY="pair.$BRANCH.coauthor"
+    SOURCE="branch"
+  fi
+  PAIRS=$(git config --get-all "$KEY" 2>/dev/null)
+else
+  CONFIG_FILE="$GIT_DIR/gh-pair/pairs.json"
+  if [ -f "$GIT_DIR/gh-pair/branches/$BRANCH.json" ]; then
+    CONFIG_FILE="$GIT_DIR/gh-pair/branches/$BRANCH.json"
+    SOURCE="branch"
+  fi
+  PAIRS=$(awk '
+    /"name":/ {
+      gsub(/.*"name": *"/, "");
+      gsub(/".*/, "");
+      print
+    }
+  ' "$CONFIG_FILE" 2>/dev/null)
+fi
+
+if [ -n "$PAIRS" ]; then
+  echo "gh-pair: co-authors on $BRANCH ($SOURCE pairs):"
+  echo "$PAIRS" | sed 's/^/  /'
+elif [ "$SOURCE" = "branch" ]; then
+  echo "gh-pair: no co-authors on $BRANCH"
+fi
+
+exit 0
+`
+
+// hookMarker identifies hooks written by gh-pair.
+const hookMarker = "# gh-pair: "
+
+// hooks are the scripts gh-pair installs, by hook name.
+var hooks = []struct {
+	name   string
+	script string
+}{
+	{"commit-msg", hookScript},
+	{"post-checkout", postCheckoutScript},
+}
+
+// branchReminder is whether the post-checkout hook is installed to list the
+// pairs after switching branches.
+var branchReminder = true
+
+// SetBranchReminder sets whether Install adds the post-checkout hook. When
+// off, Install removes it.
+func SetBranchReminder(on bool) {
+	branchReminder = on
+}
+
+// wanted reports whether a hook should be installed.
+func wanted(name string) bool {
+	return name != "post-checkout" || branchReminder
+}
+
+// Install installs the commit-msg and post-checkout hooks, backing up any
+// existing hooks that aren't ours. The post-checkout hook is removed instead
+// if the branch reminder is off.
 func Install() error {
 	hooksDir, err := git.HooksDir()
 	if err != nil {
@@ -93,8 +201,24 @@ func Install() error {
 		os.Remove(oldHookPath)
 	}
 
-	hookPath := filepath.Join(hooksDir, "commit-msg")
+	for _, h := range hooks {
+		path := filepath.Join(hooksDir, h.name)
+		if !wanted(h.name) {
+			if err := uninstallHook(path); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := installHook(path, h.script); err != nil {
+			return err
+		}
+	}
 
+	return nil
+}
+
+// installHook writes a hook script, backing up an existing hook that isn't ours.
+func installHook(hookPath, script string) error {
 	// Check if hook already exists
 	if _, err := os.Stat(hookPath); err == nil {
 		// Read existing hook to check if it's ours
@@ -114,14 +238,10 @@ func Install() error {
 	}
 
 	// Write the hook
-	if err := os.WriteFile(hookPath, []byte(hookScript), 0755); err != nil {
-		return err
-	}
-
-	return nil
+	return os.WriteFile(hookPath, []byte(script), 0755)
 }
 
-// Uninstall removes the commit-msg hook if it's ours.
+// Uninstall removes our hooks, restoring any backed up hooks.
 func Uninstall() error {
 	hooksDir, err := git.HooksDir()
 	if err != nil {
@@ -134,8 +254,17 @@ func Uninstall() error {
 		os.Remove(oldHookPath)
 	}
 
-	hookPath := filepath.Join(hooksDir, "commit-msg")
+	for _, h := range hooks {
+		if err := uninstallHook(filepath.Join(hooksDir, h.name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
+// uninstallHook removes a hook if it's ours and restores its backup.
+func uninstallHook(hookPath string) error {
 	// Check if hook exists
 	content, err := os.ReadFile(hookPath)
 	if err != nil {
@@ -166,7 +295,7 @@ func Uninstall() error {
 	return nil
 }
 
-// IsInstalled checks if the gh-pair hook is installed.
+// IsInstalled checks if the gh-pair commit-msg hook is installed.
 func IsInstalled() bool {
 	hooksDir, err := git.HooksDir()
 	if err != nil {
@@ -182,6 +311,30 @@ func IsInstalled() bool {
 	return isOurHook(string(content))
 }
 
+// IsCurrent checks if the installed hooks match the ones this version of
+// gh-pair would install, so outdated hooks can be refreshed.
+func IsCurrent() bool {
+	hooksDir, err := git.HooksDir()
+	if err != nil {
+		return false
+	}
+
+	for _, h := range hooks {
+		content, err := os.ReadFile(filepath.Join(hooksDir, h.name))
+		if !wanted(h.name) {
+			if err == nil && isOurHook(string(content)) {
+				return false
+			}
+			continue
+		}
+		if err != nil || string(content) != h.script {
+			return false
+		}
+	}
+
+	return true
+}
+
 // HasOldHook checks if the old prepare-commit-msg hook exists and is ours.
 func HasOldHook() bool {
 	hooksDir, err := git.HooksDir()
@@ -221,7 +374,6 @@ func RemoveOldHook() error {
 }
 
 func isOurHook(content string) bool {
-	return len(content) > len(hookMarker) && content[0:len(hookMarker)] == hookMarker ||
-		(len(content) > 10 && content[0:10] == "#!/bin/sh\n" && len(content) > len("#!/bin/sh\n"+hookMarker) &&
-			content[10:10+len(hookMarker)] == hookMarker)
+	return strings.HasPrefix(content, hookMarker) ||
+		strings.HasPrefix(content, "#!/bin/sh\n"+hookMarker)
 }
@@ -0,0 +1,58 @@
+package hook
+
+import (
+	"strings"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+	"github.com/omgitsads/gh-pair/internal/git"
+)
+
+// trailerIfExists is the git interpret-trailers --if-exists action used
+// both here and by the commit-msg hook: a co-author is only added if the
+// message doesn't already have the same trailer.
+const trailerIfExists = "addIfDifferent"
+
+// AddTrailers adds a Co-Authored-By trailer for each pair to a commit
+// message the way the commit-msg hook does at commit time: with git
+// interpret-trailers, skipping trailers the message already has. An empty
+// message (ignoring comments) or one that already credits every pair is
+// returned unchanged.
+func AddTrailers(message string, pairs []config.Pair) (string, error) {
+	if !hasContent(message) {
+		return message, nil
+	}
+
+	existing := make(map[config.Pair]bool)
+	for _, line := range strings.Split(message, "\n") {
+		if p, ok := config.ParseCoAuthorLine(line); ok {
+			existing[p] = true
+		}
+	}
+
+	var trailers []string
+	for _, p := range pairs {
+		credit := config.Pair{Name: p.Name, Email: p.Email}
+		if !existing[credit] {
+			existing[credit] = true
+			trailers = append(trailers, p.CoAuthorLine())
+		}
+	}
+	if len(trailers) == 0 {
+		return message, nil
+	}
+
+	return git.InterpretTrailers(message, trailerIfExists, trailers)
+}
+
+// hasContent reports whether the message has any non-comment, non-blank lines.
+func hasContent(message string) bool {
+	for _, line := range strings.Split(message, "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			return true
+		}
+	}
+	return false
+}
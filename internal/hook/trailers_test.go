@@ -0,0 +1,92 @@
+package hook
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+)
+
+func TestAddTrailers(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("HOME", t.TempDir())
+
+	alice := config.Pair{Username: "alice", Name: "Alice", Email: "alice@example.com"}
+	bob := config.Pair{Username: "bob", Name: "Bob", Email: "bob@example.com"}
+
+	tests := []struct {
+		name    string
+		message string
+		pairs   []config.Pair
+		want    string
+	}{
+		{
+			name:    "empty message",
+			message: "",
+			pairs:   []config.Pair{alice},
+			want:    "",
+		},
+		{
+			name:    "only comments",
+			message: "\n# Please enter the commit message\n",
+			pairs:   []config.Pair{alice},
+			want:    "\n# Please enter the commit message\n",
+		},
+		{
+			name:    "no pairs",
+			message: "Fix bug",
+			want:    "Fix bug",
+		},
+		{
+			name:    "subject only",
+			message: "Fix bug\n",
+			pairs:   []config.Pair{alice, bob},
+			want:    "Fix bug\n\nCo-Authored-By: Alice <alice@example.com>\nCo-Authored-By: Bob <bob@example.com>\n",
+		},
+		{
+			name:    "joins an existing trailer block",
+			message: "Fix bug\n\nSigned-off-by: Me <me@example.com>\n",
+			pairs:   []config.Pair{alice},
+			want:    "Fix bug\n\nSigned-off-by: Me <me@example.com>\nCo-Authored-By: Alice <alice@example.com>\n",
+		},
+		{
+			name:    "already credits every pair",
+			message: "Fix bug\n\nco-authored-by: Alice <alice@example.com>",
+			pairs:   []config.Pair{alice},
+			want:    "Fix bug\n\nco-authored-by: Alice <alice@example.com>",
+		},
+		{
+			name:    "adds only missing pairs",
+			message: "Fix bug\n\nCo-Authored-By: Alice <alice@example.com>\n",
+			pairs:   []config.Pair{alice, bob},
+			want:    "Fix bug\n\nCo-Authored-By: Alice <alice@example.com>\nCo-Authored-By: Bob <bob@example.com>\n",
+		},
+		{
+			name:    "duplicate pairs are added once",
+			message: "Fix bug\n",
+			pairs:   []config.Pair{alice, alice},
+			want:    "Fix bug\n\nCo-Authored-By: Alice <alice@example.com>\n",
+		},
+		{
+			name:    "a new name for the same email is added",
+			message: "Fix bug\n\nCo-Authored-By: Al <alice@example.com>\n",
+			pairs:   []config.Pair{alice},
+			want:    "Fix bug\n\nCo-Authored-By: Al <alice@example.com>\nCo-Authored-By: Alice <alice@example.com>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AddTrailers(tt.message, tt.pairs)
+			if err != nil {
+				t.Fatalf("AddTrailers() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("AddTrailers() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Package settings reads and writes the global gh-pair configuration in
+// ~/.config/gh-pair/config.json.
+package settings
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/omgitsads/gh-pair/internal/fileutil"
+)
+
+// Config represents the global gh-pair configuration.
+type Config struct {
+	Theme          string              `json:"theme"`
+	Storage        string              `json:"storage,omitempty"`        // pairs backend older versions used for all repositories
+	StorageScope   string              `json:"storageScope,omitempty"`   // its git config scope; repositories now choose their own
+	RecentSize     int                 `json:"recentSize,omitempty"`     // unpinned recent pairs to keep (default 10)
+	SessionExpiry  string              `json:"sessionExpiry,omitempty"`  // clear pairs unchanged for this long, e.g. "8h"
+	BranchReminder *bool               `json:"branchReminder,omitempty"` // list pairs after checkout (default true)
+	Keys           map[string][]string `json:"keys,omitempty"`           // TUI key bindings by name, replacing the defaults
+	Accessible     bool                `json:"accessible,omitempty"`     // use plain prompts instead of the TUI
+}
+
+// Expiry returns how long pairs last without changes, or 0 if they don't
+// expire.
+func (c Config) Expiry() time.Duration {
+	d, err := time.ParseDuration(c.SessionExpiry)
+	if err != nil || d < 0 {
+		return 0
+	}
+	return d
+}
+
+// Reminder reports whether the post-checkout hook lists the pairs.
+func (c Config) Reminder() bool {
+	return c.BranchReminder == nil || *c.BranchReminder
+}
+
+// configDir returns the path to the gh-pair config directory (~/.config/gh-pair).
+func configDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "gh-pair"), nil
+}
+
+// configPath returns the path to the global config file.
+func configPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.json"), nil
+}
+
+// Load loads the global configuration from ~/.config/gh-pair/config.json.
+// Returns default config if file doesn't exist.
+func Load() Config {
+	path, err := configPath()
+	if err != nil {
+		return Config{Theme: "default"}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{Theme: "default"}
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{Theme: "default"}
+	}
+
+	if cfg.Theme == "" {
+		cfg.Theme = "default"
+	}
+
+	return cfg
+}
+
+// Update loads the global configuration, changes it with fn and saves it
+// to ~/.config/gh-pair/config.json, holding a lock on the directory so
+// concurrent updates aren't lost. It returns the saved configuration.
+func Update(fn func(*Config)) (Config, error) {
+	dir, err := configDir()
+	if err != nil {
+		return Config{}, err
+	}
+
+	var cfg Config
+	err = fileutil.WithLock(dir, func() error {
+		cfg = Load()
+		fn(&cfg)
+
+		data, err := json.MarshalIndent(cfg, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		// The commit hook reads this file, so never leave it half written
+		return fileutil.WriteAtomic(filepath.Join(dir, "config.json"), data, 0644)
+	})
+	return cfg, err
+}
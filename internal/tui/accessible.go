@@ -0,0 +1,475 @@
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+	"github.com/omgitsads/gh-pair/internal/github"
+	"github.com/omgitsads/gh-pair/internal/history"
+	"github.com/omgitsads/gh-pair/internal/hook"
+)
+
+// errQuit ends the accessible flow, at the user's request or the end of
+// input.
+var errQuit = errors.New("quit")
+
+// accessible is a linear, prompt based alternative to the TUI for screen
+// readers and dumb terminals. It writes plain lines of text without colors,
+// emoji or animation, and every choice is made by typing a number.
+type accessible struct {
+	in  *bufio.Scanner
+	out io.Writer
+
+	currentUser   string
+	collaborators []config.Pair
+}
+
+// menuItem is an action offered by the main menu.
+type menuItem struct {
+	label string
+	run   func() error
+}
+
+// RunAccessible runs the accessible flow, reading answers from in and
+// writing to out, until the user quits or input ends. Failed actions are
+// reported and the menu is shown again; an error loading the pairs or
+// reading input ends the flow.
+func RunAccessible(in io.Reader, out io.Writer) error {
+	a := &accessible{in: bufio.NewScanner(in), out: out}
+	a.println("gh-pair, accessible mode. Type a number and press Enter to choose.")
+
+	a.currentUser, _ = github.GetAuthenticatedUser()
+	collaborators, _ := github.GetRepoCollaborators()
+	a.collaborators = filterOutUser(collaborators, a.currentUser)
+
+	for {
+		if err := a.mainMenu(); errors.Is(err, errQuit) {
+			a.println("Goodbye.")
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+func (a *accessible) println(s string) {
+	fmt.Fprintln(a.out, s)
+}
+
+// ask prompts for a line of text.
+func (a *accessible) ask(prompt string) (string, error) {
+	fmt.Fprint(a.out, prompt+": ")
+	if !a.in.Scan() {
+		a.println("")
+		if err := a.in.Err(); err != nil {
+			return "", err
+		}
+		return "", errQuit
+	}
+	return strings.TrimSpace(a.in.Text()), nil
+}
+
+// choose lists numbered options and returns the index of the one picked,
+// or -1 if the user picked 0 to go back.
+func (a *accessible) choose(title string, options []string, back string) (int, error) {
+	a.println("")
+	a.println(title)
+	for i, o := range options {
+		a.println(fmt.Sprintf("  %d. %s", i+1, o))
+	}
+	a.println("  0. " + back)
+
+	for {
+		answer, err := a.ask(fmt.Sprintf("Choose 0 to %d", len(options)))
+		if err != nil {
+			return 0, err
+		}
+		n, err := strconv.Atoi(answer)
+		if err == nil && n >= 0 && n <= len(options) {
+			return n - 1, nil
+		}
+		a.println(fmt.Sprintf("Please type a number from 0 to %d.", len(options)))
+	}
+}
+
+// confirm asks a yes or no question.
+func (a *accessible) confirm(question string) (bool, error) {
+	answer, err := a.ask(question + " Type y for yes or n for no")
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(answer, "y") || strings.EqualFold(answer, "yes"), nil
+}
+
+// mainMenu describes the current pairs and runs the chosen action.
+func (a *accessible) mainMenu() error {
+	pairs, err := config.LoadPairs()
+	if err != nil {
+		return fmt.Errorf("failed to load pairs: %w", err)
+	}
+
+	a.println("")
+	a.describePairs(pairs.Pairs)
+	installed := hook.IsInstalled()
+	if !installed {
+		a.println("The git hook is not installed, so commits won't get co-author trailers.")
+	}
+
+	items := []menuItem{
+		{"Add a pair by searching GitHub", a.search},
+		{"Add a pair from one of your teams", a.teams},
+		{"Add a suggested pair", a.suggestions},
+	}
+	if len(pairs.Pairs) > 0 {
+		items = append(items,
+			menuItem{"Remove a pair", func() error { return a.remove(pairs.Pairs) }},
+			menuItem{"Edit a pair's name or email", func() error { return a.edit(pairs.Pairs) }},
+			menuItem{"Clear all pairs", func() error { return a.clear(len(pairs.Pairs)) }},
+		)
+	}
+	items = append(items,
+		menuItem{"Undo the last change", a.undo},
+		menuItem{"Redo", a.redo},
+	)
+	if !installed {
+		items = append(items, menuItem{"Install the git hook", a.installHook})
+	}
+
+	labels := make([]string, len(items))
+	for i, item := range items {
+		labels[i] = item.label
+	}
+	i, err := a.choose("What would you like to do?", labels, "Quit")
+	if err != nil {
+		return err
+	}
+	if i < 0 {
+		return errQuit
+	}
+
+	err = items[i].run()
+	if err != nil && !errors.Is(err, errQuit) {
+		// The menu still works after a failed action
+		a.println("Error: " + err.Error())
+		return nil
+	}
+	return err
+}
+
+// describePairs lists the pairs that apply to new commits.
+func (a *accessible) describePairs(pairs []config.Pair) {
+	on := ""
+	if branch := config.ActiveBranch(); branch != "" {
+		on = " on branch " + branch
+	}
+
+	if len(pairs) == 0 {
+		a.println("No pairs configured" + on + ".")
+		return
+	}
+	noun := "pairs"
+	if len(pairs) == 1 {
+		noun = "pair"
+	}
+	a.println(fmt.Sprintf("%d current %s%s:", len(pairs), noun, on))
+	for _, p := range pairs {
+		a.println("  " + pairLabel(p))
+	}
+}
+
+// pairLabel describes a pair on one line.
+func pairLabel(p config.Pair) string {
+	if p.Email == "" {
+		return "@" + p.Username + ", " + p.Name
+	}
+	return "@" + p.Username + ", " + p.Name + " <" + p.Email + ">"
+}
+
+// search finds users matching a query, recent pairs and collaborators first,
+// and adds the one chosen.
+func (a *accessible) search() error {
+	query, err := a.ask("Search for a GitHub user, or leave blank to go back")
+	if err != nil || query == "" {
+		return err
+	}
+
+	recent, err := config.LoadRecent()
+	if err != nil {
+		return err
+	}
+	local, _ := fuzzyPairs(query, append(filterOutUser(recent.Recent, a.currentUser), a.collaborators...))
+
+	a.println("Searching GitHub...")
+	found, err := github.SearchUsers(query)
+	if err != nil {
+		a.println("Error: " + err.Error())
+	}
+
+	var users []config.Pair
+	for _, p := range append(local, filterOutUser(found, a.currentUser)...) {
+		if !containsUser(users, p.Username) {
+			users = append(users, p)
+		}
+	}
+	if len(users) == 0 {
+		a.println("No users found for " + query + ".")
+		return nil
+	}
+
+	return a.chooseAndAdd(fmt.Sprintf("%d users found. Which one do you want to add?", len(users)), users)
+}
+
+// teams adds one member, or every member, of a team.
+func (a *accessible) teams() error {
+	a.println("Loading your teams...")
+	teams, err := github.GetUserTeams()
+	if err != nil {
+		return err
+	}
+	if len(teams) == 0 {
+		a.println("No teams found. You may not be a member of any GitHub teams.")
+		return nil
+	}
+
+	labels := make([]string, len(teams))
+	for i, t := range teams {
+		labels[i] = t.Name + ", " + t.Org + "/" + t.Slug
+	}
+	i, err := a.choose("Which team?", labels, "Back")
+	if err != nil || i < 0 {
+		return err
+	}
+	team := teams[i]
+
+	a.println("Loading members of " + team.Name + "...")
+	members, err := github.GetTeamMembers(team.Org, team.Slug)
+	if err != nil {
+		return err
+	}
+	pairs, err := config.LoadPairs()
+	if err != nil {
+		return err
+	}
+	var candidates []config.Pair
+	for _, p := range filterOutUser(members, a.currentUser) {
+		if !containsUser(pairs.Pairs, p.Username) {
+			candidates = append(candidates, p)
+		}
+	}
+	if len(candidates) == 0 {
+		a.println("Everyone in " + team.Name + " is already a pair.")
+		return nil
+	}
+
+	labels = []string{fmt.Sprintf("Everyone listed below (%d)", len(candidates))}
+	for _, p := range candidates {
+		labels = append(labels, userLabel(p))
+	}
+	i, err = a.choose("Who do you want to add from "+team.Name+"?", labels, "Back")
+	if err != nil || i < 0 {
+		return err
+	}
+	if i > 0 {
+		return a.add(candidates[i-1].Username)
+	}
+	return a.addAll(candidates)
+}
+
+// suggestions adds one of the people you've paired with least recently.
+func (a *accessible) suggestions() error {
+	a.println("Loading suggestions...")
+	roster, err := github.GetTeammates("")
+	if err != nil {
+		return err
+	}
+	suggestions, err := history.SuggestFromRoster(roster, a.currentUser)
+	if err != nil {
+		return err
+	}
+	if len(suggestions) == 0 {
+		a.println("No suggestions.")
+		return nil
+	}
+
+	now := time.Now()
+	labels := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		labels[i] = "@" + s.Pair.Username + ", " + history.Ago(s.LastPaired, now)
+	}
+	i, err := a.choose("Suggested pairs, least recently paired first. Which one do you want to add?", labels, "Back")
+	if err != nil || i < 0 {
+		return err
+	}
+	return a.add(suggestions[i].Pair.Username)
+}
+
+// chooseAndAdd adds the user picked from a list.
+func (a *accessible) chooseAndAdd(title string, users []config.Pair) error {
+	labels := make([]string, len(users))
+	for i, p := range users {
+		labels[i] = userLabel(p)
+	}
+	i, err := a.choose(title, labels, "Back")
+	if err != nil || i < 0 {
+		return err
+	}
+	return a.add(users[i].Username)
+}
+
+// userLabel describes a user found on GitHub, whose email may be unknown.
+func userLabel(p config.Pair) string {
+	if p.Name == "" || p.Name == p.Username {
+		return "@" + p.Username
+	}
+	return "@" + p.Username + ", " + p.Name
+}
+
+// add looks a user up on GitHub and adds them as a pair.
+func (a *accessible) add(username string) error {
+	pair, err := github.LookupUser(username)
+	if err != nil {
+		return err
+	}
+	if err := config.AddPair(*pair); err != nil {
+		return err
+	}
+	a.println("Added " + pairLabel(*pair) + ".")
+	return nil
+}
+
+// addAll looks several users up on GitHub and adds them as pairs.
+func (a *accessible) addAll(users []config.Pair) error {
+	var pairs []config.Pair
+	for _, u := range users {
+		pair, err := github.LookupUser(u.Username)
+		if err != nil {
+			a.println("Couldn't look up @" + u.Username + ": " + err.Error())
+			continue
+		}
+		pairs = append(pairs, *pair)
+	}
+
+	added, err := config.AddPairs(pairs)
+	if err != nil {
+		return err
+	}
+	a.println(fmt.Sprintf("Added %d pairs.", added))
+	return nil
+}
+
+// remove removes the pair picked, once confirmed.
+func (a *accessible) remove(pairs []config.Pair) error {
+	p, ok, err := a.choosePair("Which pair do you want to remove?", pairs)
+	if err != nil || !ok {
+		return err
+	}
+	if yes, err := a.confirm("Remove @" + p.Username + "?"); err != nil || !yes {
+		return err
+	}
+	if err := config.RemovePair(p.Username); err != nil {
+		return err
+	}
+	a.println("Removed @" + p.Username + ".")
+	return nil
+}
+
+// edit changes the name or email a pair is credited with.
+func (a *accessible) edit(pairs []config.Pair) error {
+	p, ok, err := a.choosePair("Which pair do you want to edit?", pairs)
+	if err != nil || !ok {
+		return err
+	}
+
+	name, err := a.ask("Name, or leave blank to keep " + p.Name)
+	if err != nil {
+		return err
+	}
+	email, err := a.ask("Email, or leave blank to keep " + p.Email)
+	if err != nil {
+		return err
+	}
+	if name != "" {
+		p.Name = name
+	}
+	if email != "" {
+		p.Email = email
+	}
+
+	if err := config.EditPair(p); err != nil {
+		return err
+	}
+	a.println("Saved " + pairLabel(p) + ".")
+	return nil
+}
+
+// choosePair picks one of the current pairs.
+func (a *accessible) choosePair(title string, pairs []config.Pair) (config.Pair, bool, error) {
+	labels := make([]string, len(pairs))
+	for i, p := range pairs {
+		labels[i] = pairLabel(p)
+	}
+	i, err := a.choose(title, labels, "Back")
+	if err != nil || i < 0 {
+		return config.Pair{}, false, err
+	}
+	return pairs[i], true, nil
+}
+
+func (a *accessible) clear(count int) error {
+	if yes, err := a.confirm(fmt.Sprintf("Clear all %d pairs?", count)); err != nil || !yes {
+		return err
+	}
+	if err := config.ClearPairs(); err != nil {
+		return err
+	}
+	a.println("Cleared all pairs.")
+	return nil
+}
+
+func (a *accessible) undo() error {
+	entry, err := config.Undo()
+	if errors.Is(err, config.ErrNothingToUndo) {
+		a.println("Nothing to undo.")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	a.println("Undid: " + entry.Action + ".")
+	return nil
+}
+
+func (a *accessible) redo() error {
+	entry, err := config.Redo()
+	if errors.Is(err, config.ErrNothingToRedo) {
+		a.println("Nothing to redo.")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	a.println("Redid: " + entry.Action + ".")
+	return nil
+}
+
+func (a *accessible) installHook() error {
+	if err := hook.Install(); err != nil {
+		return err
+	}
+	a.println("Installed the git hook.")
+	return nil
+}
+
+// containsUser reports whether pairs includes a user.
+func containsUser(pairs []config.Pair, username string) bool {
+	return slices.ContainsFunc(pairs, func(p config.Pair) bool {
+		return strings.EqualFold(p.Username, username)
+	})
+}
@@ -14,7 +14,7 @@ func Run() error {
 // RunWithTheme starts the TUI application with the specified theme.
 func RunWithTheme(themeName string) error {
 	m := NewModelWithTheme(themeName)
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
 
 	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("error running TUI: %w", err)
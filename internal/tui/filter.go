@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sahilm/fuzzy"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+	"github.com/omgitsads/gh-pair/internal/github"
+)
+
+// highlighter is implemented by list items that know which runes of their
+// title and description matched the filter.
+type highlighter interface {
+	Highlights() (title, desc []int)
+}
+
+// fuzzyFind ranks sources by how well they match query, best first. An
+// empty query matches every source in order.
+func fuzzyFind(query string, sources []string) fuzzy.Matches {
+	query = strings.TrimPrefix(strings.TrimSpace(query), "@")
+	if query == "" {
+		matches := make(fuzzy.Matches, len(sources))
+		for i, s := range sources {
+			matches[i] = fuzzy.Match{Str: s, Index: i}
+		}
+		return matches
+	}
+	return fuzzy.Find(query, sources)
+}
+
+// splitMatch divides the matched byte offsets of a source built as
+// first + " " + second into rune offsets within each part.
+func splitMatch(m fuzzy.Match, first string) (a, b []int) {
+	second := m.Str[min(len(first)+1, len(m.Str)):]
+	for _, i := range m.MatchedIndexes {
+		switch {
+		case i < len(first):
+			a = append(a, utf8.RuneCountInString(first[:i]))
+		case i > len(first):
+			b = append(b, utf8.RuneCountInString(second[:i-len(first)-1]))
+		}
+	}
+	return a, b
+}
+
+// fuzzyPairs filters pairs by username and name.
+func fuzzyPairs(query string, pairs []config.Pair) ([]config.Pair, [][2][]int) {
+	sources := make([]string, len(pairs))
+	for i, p := range pairs {
+		sources[i] = p.Username + " " + p.Name
+	}
+
+	matches := fuzzyFind(query, sources)
+	filtered := make([]config.Pair, len(matches))
+	highlights := make([][2][]int, len(matches))
+	for i, m := range matches {
+		p := pairs[m.Index]
+		filtered[i] = p
+		highlights[i][0], highlights[i][1] = splitMatch(m, p.Username)
+	}
+	return filtered, highlights
+}
+
+// fuzzyTeams filters teams by name and org/slug.
+func fuzzyTeams(query string, teams []github.Team) ([]github.Team, [][2][]int) {
+	sources := make([]string, len(teams))
+	for i, t := range teams {
+		sources[i] = t.Name + " " + t.Org + "/" + t.Slug
+	}
+
+	matches := fuzzyFind(query, sources)
+	filtered := make([]github.Team, len(matches))
+	highlights := make([][2][]int, len(matches))
+	for i, m := range matches {
+		t := teams[m.Index]
+		filtered[i] = t
+		highlights[i][0], highlights[i][1] = splitMatch(m, t.Name)
+	}
+	return filtered, highlights
+}
+
+// highlightDelegate renders items like list.DefaultDelegate, styling the
+// runes that matched our own fuzzy filter. Each item is marked as a zone
+// named after the list and its index so it can be clicked, and removable
+// items get a remove button.
+type highlightDelegate struct {
+	list.DefaultDelegate
+	zones     *zoneMap
+	zone      string
+	removable bool
+}
+
+func newHighlightDelegate(zones *zoneMap, zone string) highlightDelegate {
+	return highlightDelegate{DefaultDelegate: list.NewDefaultDelegate(), zones: zones, zone: zone}
+}
+
+func (d highlightDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	var b strings.Builder
+	d.render(&b, m, index, item)
+	io.WriteString(w, d.zones.mark(fmt.Sprintf("%s:%d", d.zone, index), b.String())) //nolint: errcheck
+}
+
+func (d highlightDelegate) render(w io.Writer, m list.Model, index int, item list.Item) {
+	h, ok := item.(highlighter)
+	if !ok {
+		d.DefaultDelegate.Render(w, m, index, item)
+		return
+	}
+	i, ok := item.(list.DefaultItem)
+	if !ok || m.Width() <= 0 {
+		return
+	}
+
+	s := &d.Styles
+	textwidth := m.Width() - s.NormalTitle.GetPaddingLeft() - s.NormalTitle.GetPaddingRight()
+	var remove string
+	if d.removable {
+		remove = " " + d.zones.mark(fmt.Sprintf("remove:%d", index), lipgloss.NewStyle().Foreground(s.DimmedTitle.GetForeground()).Render("✕"))
+		textwidth -= 2
+	}
+	title := ansi.Truncate(i.Title(), textwidth, "…")
+	desc := ansi.Truncate(i.Description(), textwidth, "…")
+	titleMatches, descMatches := h.Highlights()
+
+	titleStyle, descStyle := s.NormalTitle, s.NormalDesc
+	if index == m.Index() {
+		titleStyle, descStyle = s.SelectedTitle, s.SelectedDesc
+	}
+	title = highlight(title, titleMatches, titleStyle, s.FilterMatch)
+	desc = highlight(desc, descMatches, descStyle, s.FilterMatch)
+
+	if d.ShowDescription {
+		fmt.Fprintf(w, "%s%s\n%s", titleStyle.Render(title), remove, descStyle.Render(desc)) //nolint: errcheck
+		return
+	}
+	fmt.Fprintf(w, "%s%s", titleStyle.Render(title), remove) //nolint: errcheck
+}
+
+// highlight styles the runes of s at the given offsets with match.
+func highlight(s string, runes []int, base, match lipgloss.Style) string {
+	if len(runes) == 0 {
+		return s
+	}
+	unmatched := base.Inline(true)
+	return lipgloss.StyleRunes(s, runes, unmatched.Inherit(match), unmatched)
+}
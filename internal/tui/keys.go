@@ -0,0 +1,173 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// keyMap holds the TUI's key bindings. Each can be rebound in the global
+// config under "keys", using the names listed in entries.
+type keyMap struct {
+	Search      key.Binding
+	Teams       key.Binding
+	Suggest     key.Binding
+	PrevTab     key.Binding
+	NextTab     key.Binding
+	Remove      key.Binding
+	Edit        key.Binding
+	Clear       key.Binding
+	Undo        key.Binding
+	Redo        key.Binding
+	InstallHook key.Binding
+	Retry       key.Binding
+	Settings    key.Binding
+	Select      key.Binding
+	AddTeam     key.Binding
+	Pin         key.Binding
+	Forget      key.Binding
+	Up          key.Binding
+	Down        key.Binding
+	Less        key.Binding
+	More        key.Binding
+	Focus       key.Binding
+	Confirm     key.Binding
+	Back        key.Binding
+	Help        key.Binding
+	Quit        key.Binding
+}
+
+// keyEntry names a binding for the config and describes it in the help view.
+type keyEntry struct {
+	name    string
+	desc    string
+	binding *key.Binding
+}
+
+func defaultKeyMap() keyMap {
+	return keyMap{
+		Search:      binding("search", "a", "/"),
+		Teams:       binding("teams", "t"),
+		Suggest:     binding("suggest", "s"),
+		PrevTab:     binding("previous tab", "shift+tab", "ctrl+pgup"),
+		NextTab:     binding("next tab", "ctrl+pgdown"),
+		Remove:      binding("remove", "d", "delete", "backspace"),
+		Edit:        binding("edit", "e"),
+		Clear:       binding("clear", "c"),
+		Undo:        binding("undo", "u"),
+		Redo:        binding("redo", "ctrl+r"),
+		InstallHook: binding("install hook", "i"),
+		Retry:       binding("retry", "r"),
+		Settings:    binding("settings", ","),
+		Select:      binding("select", " "),
+		AddTeam:     binding("add whole team", "A"),
+		Pin:         binding("pin/unpin recent", "p"),
+		Forget:      binding("forget recent", "x"),
+		Up:          binding("up", "up"),
+		Down:        binding("down", "down"),
+		Less:        binding("previous value", "left", "h"),
+		More:        binding("next value", "right", "l"),
+		Focus:       binding("switch focus", "tab"),
+		Confirm:     binding("select", "enter"),
+		Back:        binding("back", "esc"),
+		Help:        binding("help", "?"),
+		Quit:        binding("quit", "q"),
+	}
+}
+
+// newKeyMap returns the default bindings with the given overrides, keyed by
+// binding name. Unknown names are ignored.
+func newKeyMap(overrides map[string][]string) keyMap {
+	k := defaultKeyMap()
+	for _, e := range k.entries() {
+		keys := slices.Clone(overrides[e.name])
+		if len(keys) == 0 {
+			continue
+		}
+		for i, s := range keys {
+			if s == "space" {
+				keys[i] = " "
+			}
+		}
+		*e.binding = binding(e.binding.Help().Desc, keys...)
+	}
+	return k
+}
+
+// entries lists the bindings in the order the help view shows them.
+func (k *keyMap) entries() []keyEntry {
+	return []keyEntry{
+		{"search", "Search GitHub users", &k.Search},
+		{"teams", "Browse your teams", &k.Teams},
+		{"suggest", "Suggest who to pair with", &k.Suggest},
+		{"prevTab", "Previous tab, or previous field", &k.PrevTab},
+		{"nextTab", "Next tab", &k.NextTab},
+		{"remove", "Remove selected pair", &k.Remove},
+		{"edit", "Edit selected pair's name or email", &k.Edit},
+		{"clear", "Clear all pairs", &k.Clear},
+		{"undo", "Undo last change", &k.Undo},
+		{"redo", "Redo", &k.Redo},
+		{"installHook", "Install git hook", &k.InstallHook},
+		{"retry", "Retry what just failed (when not typing)", &k.Retry},
+		{"settings", "Open settings", &k.Settings},
+		{"select", "Select users to add together (search list)", &k.Select},
+		{"addTeam", "Add every member of a team (teams list)", &k.AddTeam},
+		{"pin", "Pin or unpin a recent pair (search list)", &k.Pin},
+		{"forget", "Forget a recent pair (search list)", &k.Forget},
+		{"up", "Move up the list, or to the previous field", &k.Up},
+		{"down", "Move down the list, or to the next field", &k.Down},
+		{"less", "Previous value (settings)", &k.Less},
+		{"more", "Next value (settings)", &k.More},
+		{"focus", "Switch between input and list, or to the next field", &k.Focus},
+		{"confirm", "Select / Confirm", &k.Confirm},
+		{"back", "Cancel / Back", &k.Back},
+		{"help", "Toggle help", &k.Help},
+		{"quit", "Quit (when not typing)", &k.Quit},
+	}
+}
+
+func binding(desc string, keys ...string) key.Binding {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = keyName(k)
+	}
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(strings.Join(names, ", "), desc))
+}
+
+// firstKey returns how the first key of a binding is shown in footers.
+func firstKey(b key.Binding) string {
+	if keys := b.Keys(); len(keys) > 0 {
+		return keyName(keys[0])
+	}
+	return ""
+}
+
+// keyName returns how a key is shown in the help view and footers.
+func keyName(k string) string {
+	switch k {
+	case " ":
+		return "Space"
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	}
+	if len(k) == 1 {
+		return k
+	}
+
+	parts := strings.Split(k, "+")
+	for i, p := range parts {
+		if len(p) > 1 {
+			parts[i] = strings.ToUpper(p[:1]) + p[1:]
+		} else {
+			parts[i] = strings.ToUpper(p)
+		}
+	}
+	return strings.Join(parts, "+")
+}
@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	// splitMinWidth is the narrowest terminal that keeps the current pairs
+	// in a column beside the search, teams and suggestions views.
+	splitMinWidth = 110
+
+	// sidebarWidth is the width of the current pairs column.
+	sidebarWidth = 34
+)
+
+// split reports whether the terminal is wide enough for two columns.
+func (m Model) split() bool {
+	return m.width >= splitMinWidth
+}
+
+// contentWidth returns the width left for the active view.
+func (m Model) contentWidth() int {
+	if m.split() {
+		return m.width - sidebarWidth
+	}
+	return m.width
+}
+
+// resizeLists fits the lists to the terminal and the current layout.
+func (m *Model) resizeLists() {
+	width := m.contentWidth()
+
+	// Lists with a profile pane make room for it beside or below them
+	profileWidth, profileHeight := 0, 2
+	if m.wideProfile() {
+		profileWidth, profileHeight = profilePaneWidth, 0
+	}
+
+	m.pairList.SetSize(m.width-4, m.height-8)
+	m.searchList.SetSize(width-4-profileWidth, m.height-12-profileHeight)
+	m.teamList.SetSize(width-4, m.height-12)
+	m.suggestionList.SetSize(width-4-profileWidth, m.height-10-profileHeight)
+}
+
+// withSidebar shows the current pairs beside a view on wide terminals.
+func (m Model) withSidebar(view string) string {
+	if !m.split() {
+		return view
+	}
+	view = lipgloss.NewStyle().Width(m.contentWidth()).Render(view)
+	return lipgloss.JoinHorizontal(lipgloss.Top, m.pairsSidebar(), view)
+}
+
+// pairsSidebar renders the current pairs as a compact column.
+func (m Model) pairsSidebar() string {
+	var b strings.Builder
+
+	title := "Current Pairs"
+	if m.branch != "" {
+		title += " on " + m.branch
+	}
+	b.WriteString(m.styles.HelpKey.Render(title))
+	b.WriteString("\n\n")
+
+	width := sidebarWidth - 8 // border, padding and margin
+	if len(m.pairs) == 0 {
+		b.WriteString(m.styles.Dim.Render("No pairs yet"))
+	}
+	for i, p := range m.pairs {
+		// Leave room for the title, box and the count of pairs not shown
+		if i > 0 && (i+1)*2 > m.height-10 {
+			b.WriteString(m.styles.Dim.Render(fmt.Sprintf("…and %d more", len(m.pairs)-i)))
+			break
+		}
+		b.WriteString(ansi.Truncate("@"+p.Username, width, "…"))
+		b.WriteString("\n")
+		b.WriteString(m.styles.Dim.Render(ansi.Truncate(p.Name, width, "…")))
+		b.WriteString("\n")
+	}
+
+	return m.styles.Box.Width(sidebarWidth - 4).MarginRight(2).Render(strings.TrimRight(b.String(), "\n"))
+}
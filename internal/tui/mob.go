@@ -0,0 +1,194 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+	"github.com/omgitsads/gh-pair/internal/theme"
+)
+
+// MobModel is the countdown view for a running mob session.
+type MobModel struct {
+	session  *config.MobSession
+	styles   theme.Styles
+	now      time.Time
+	rotating bool
+	ended    bool
+	err      error
+}
+
+type (
+	mobTickMsg    time.Time
+	mobRotatedMsg struct {
+		session *config.MobSession
+		rotated bool // false if another terminal rotated first
+		err     error
+	}
+)
+
+// NewMobModel creates a countdown view for the running mob session.
+func NewMobModel(themeName string, session *config.MobSession) MobModel {
+	return MobModel{
+		session: session,
+		styles:  theme.NewStyles(theme.GetTheme(themeName)),
+		now:     time.Now(),
+	}
+}
+
+// Init starts the countdown.
+func (m MobModel) Init() tea.Cmd {
+	return mobTick()
+}
+
+// Update handles messages.
+func (m MobModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "n":
+			if !m.rotating {
+				m.rotating = true
+				return m, rotateMob
+			}
+		}
+
+	case mobTickMsg:
+		m.now = time.Time(msg)
+
+		// Pick up rotations or a stop made from another terminal
+		session, err := config.LoadMob()
+		if err != nil {
+			m.err = err
+			return m, mobTick()
+		}
+		if session == nil {
+			m.ended = true
+			return m, tea.Quit
+		}
+		m.session = session
+
+		if !m.rotating && !m.now.Before(m.session.NextRotation()) {
+			m.rotating = true
+			return m, tea.Batch(rotateMobIfDue, mobTick())
+		}
+		return m, mobTick()
+
+	case mobRotatedMsg:
+		m.rotating = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.session = msg.session
+		m.now = time.Now()
+		if !msg.rotated {
+			return m, nil
+		}
+		driver := m.session.CurrentDriver()
+		return m, notify(fmt.Sprintf("gh-pair: %s (@%s) is now driving", driver.Name, driver.Username))
+	}
+
+	return m, nil
+}
+
+// View renders the countdown.
+func (m MobModel) View() string {
+	if m.ended {
+		return "Mob session ended\n"
+	}
+
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render("🤝 gh-pair mob"))
+	b.WriteString("\n")
+
+	if m.err != nil {
+		b.WriteString(m.styles.Error.Render("Error: " + m.err.Error()))
+		b.WriteString("\n\n")
+	}
+
+	driver := m.session.CurrentDriver()
+	next := m.session.NextDriver()
+	remaining := m.session.NextRotation().Sub(m.now)
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	var content strings.Builder
+	content.WriteString(m.styles.Dim.Render("Driver"))
+	content.WriteString("\n")
+	content.WriteString(m.styles.Title.Render(fmt.Sprintf("%s (@%s)", driver.Name, driver.Username)))
+	content.WriteString("\n")
+
+	countdown := m.styles.Success
+	if remaining < time.Minute {
+		countdown = m.styles.Warning
+	}
+	content.WriteString(countdown.Render(formatCountdown(remaining)))
+	content.WriteString(m.styles.Dim.Render(fmt.Sprintf("  until @%s drives", next.Username)))
+	content.WriteString("\n\n")
+
+	content.WriteString(m.styles.Dim.Render("Co-authors"))
+	content.WriteString("\n")
+	for _, p := range m.session.Navigators() {
+		content.WriteString(fmt.Sprintf("  @%-20s %s\n", p.Username, m.styles.Dim.Render(p.Name)))
+	}
+
+	b.WriteString(m.styles.Box.Render(content.String()))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%s %s%s%s %s",
+		m.styles.HelpKey.Render("n"), m.styles.Dim.Render("next driver"),
+		m.styles.Dim.Render(" • "),
+		m.styles.HelpKey.Render("q"), m.styles.Dim.Render("close (session keeps running)")))
+
+	return b.String()
+}
+
+// formatCountdown formats a duration as mm:ss.
+func formatCountdown(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%02d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+func mobTick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return mobTickMsg(t)
+	})
+}
+
+func rotateMob() tea.Msg {
+	session, err := config.RotateMob()
+	return mobRotatedMsg{session: session, rotated: true, err: err}
+}
+
+// rotateMobIfDue rotates the driver when the countdown runs out.
+func rotateMobIfDue() tea.Msg {
+	session, rotated, err := config.RotateMobIfDue()
+	return mobRotatedMsg{session: session, rotated: rotated, err: err}
+}
+
+// notify rings the terminal bell and sends an OSC 9 desktop notification,
+// which terminals that don't support it ignore.
+func notify(text string) tea.Cmd {
+	return func() tea.Msg {
+		fmt.Fprintf(os.Stderr, "\a\x1b]9;%s\x07", text)
+		return nil
+	}
+}
+
+// RunMob shows the countdown for the running mob session.
+func RunMob(themeName string, session *config.MobSession) error {
+	p := tea.NewProgram(NewMobModel(themeName, session), tea.WithAltScreen())
+
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("error running TUI: %w", err)
+	}
+
+	return nil
+}
@@ -1,9 +1,15 @@
 package tui
 
 import (
+	"errors"
+	"fmt"
+	"slices"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -11,7 +17,9 @@ import (
 
 	"github.com/omgitsads/gh-pair/internal/config"
 	"github.com/omgitsads/gh-pair/internal/github"
+	"github.com/omgitsads/gh-pair/internal/history"
 	"github.com/omgitsads/gh-pair/internal/hook"
+	"github.com/omgitsads/gh-pair/internal/settings"
 	"github.com/omgitsads/gh-pair/internal/theme"
 )
 
@@ -26,6 +34,9 @@ const (
 	ViewTeams
 	ViewTeamMembers
 	ViewHelp
+	ViewSuggestions
+	ViewEdit
+	ViewSettings
 )
 
 // SearchTab represents which tab is active in search view.
@@ -34,37 +45,78 @@ type SearchTab int
 const (
 	TabUsers SearchTab = iota
 	TabTeams
+	TabSuggestions
 )
 
+// tabNames are the labels shown in the tab bar, indexed by SearchTab.
+var tabNames = []string{"Users", "Teams", "Suggestions"}
+
 // Model is the main TUI model.
 type Model struct {
 	view          View
 	pairs         []config.Pair
 	recentPairs   []config.Pair
+	recentPinned  []string
 	collaborators []config.Pair
 	searchResults []config.Pair
 	currentUser   string // authenticated GitHub username (filtered from results)
+	branch        string // branch whose own pairs apply, if any
 
 	// Team-related state
-	teams           []github.Team
-	filteredTeams   []github.Team
-	selectedTeam    *github.Team
-	teamMembers     []config.Pair
+	teams               []github.Team
+	filteredTeams       []github.Team
+	teamMatches         [][2][]int // matched runes of each filtered team
+	selectedTeam        *github.Team
+	teamMembers         []config.Pair
 	filteredTeamMembers []config.Pair
-	searchTab       SearchTab
+	memberMatches       [][2][]int // matched runes of each filtered member
+	searchTab           SearchTab
+
+	// Users marked with space in the search and team member views
+	selected []config.Pair
+
+	// Pair rotation suggestions
+	suggestions []history.Suggestion
 
 	// Theme and styles
-	styles        theme.Styles
-
-	pairList      list.Model
-	searchInput   textinput.Model
-	searchList    list.Model
-	teamList      list.Model
-	spinner       spinner.Model
-	loading       bool
-	focusInput    bool // request focus on search input after loading
-	hookInstalled bool
-	err           error
+	themeName string
+	styles    theme.Styles
+	keys      keyMap
+
+	// Profiles of highlighted users, fetched lazily and kept for the session
+	profiles       map[string]profileEntry
+	profilePending string // user whose profile fetch is scheduled
+	profileShown   string // user highlighted when profiles were last requested
+
+	zones *zoneMap // clickable parts of the last view
+
+	pairList       list.Model
+	searchInput    textinput.Model
+	searchList     list.Model
+	teamList       list.Model
+	suggestionList list.Model
+	spinner        spinner.Model
+	loading        bool
+	focusInput     bool // request focus on search input after loading
+	hookInstalled  bool
+	hookCurrent    bool // installed hooks match this version's
+
+	// Notifications shown below the view until they expire
+	toasts  []toast
+	toastID int
+
+	// Action waiting for the user to confirm it
+	confirmPrompt string
+	confirmAction tea.Cmd
+
+	// Edit form for the selected pair
+	editing    config.Pair
+	editInputs []textinput.Model // name, email
+	editFocus  int
+	editErr    error
+
+	// Settings being changed in the settings view
+	settings settingsForm
 
 	// Debounce state for autocomplete
 	lastQuery     string
@@ -76,27 +128,67 @@ type Model struct {
 
 // pairItem implements list.Item for pairs.
 type pairItem struct {
-	pair config.Pair
+	pair     config.Pair
+	recent   bool // from the recent list, so it can be pinned or forgotten
+	pinned   bool
+	selected bool // marked with space to be added together
+	matches  [2][]int
 }
 
-func (i pairItem) Title() string       { return "@" + i.pair.Username }
+func (i pairItem) Title() string {
+	title := "@" + i.pair.Username
+	if i.pinned {
+		title = "📌 " + title
+	}
+	if i.selected {
+		title = "✓ " + title
+	}
+	return title
+}
 func (i pairItem) Description() string { return i.pair.Name + " <" + i.pair.Email + ">" }
 func (i pairItem) FilterValue() string { return i.pair.Username + " " + i.pair.Name }
 
+// Highlights shifts username matches past the title's prefix and "@".
+func (i pairItem) Highlights() (title, desc []int) {
+	offset := utf8.RuneCountInString(i.Title()) - utf8.RuneCountInString(i.pair.Username)
+	for _, r := range i.matches[0] {
+		title = append(title, r+offset)
+	}
+	return title, i.matches[1]
+}
+
 // teamItem implements list.Item for teams.
 type teamItem struct {
-	team github.Team
+	team    github.Team
+	matches [2][]int
 }
 
 func (i teamItem) Title() string       { return i.team.Name }
 func (i teamItem) Description() string { return i.team.Org + "/" + i.team.Slug }
 func (i teamItem) FilterValue() string { return i.team.Name + " " + i.team.Slug }
+func (i teamItem) Highlights() (title, desc []int) {
+	return i.matches[0], i.matches[1]
+}
+
+// suggestionItem implements list.Item for pair suggestions.
+type suggestionItem struct {
+	suggestion history.Suggestion
+	now        time.Time
+}
+
+func (i suggestionItem) Title() string { return "@" + i.suggestion.Pair.Username }
+func (i suggestionItem) Description() string {
+	return history.Ago(i.suggestion.LastPaired, i.now)
+}
+func (i suggestionItem) FilterValue() string { return i.suggestion.Pair.Username }
 
 // Messages
 type (
 	pairsLoadedMsg struct {
 		pairs  []config.Pair
 		recent []config.Pair
+		pinned []string
+		branch string
 	}
 	collaboratorsLoadedMsg struct {
 		collaborators []config.Pair
@@ -109,12 +201,30 @@ type (
 		query   string // track which query this result is for
 	}
 	userLookedUpMsg struct {
-		pair *config.Pair
-		err  error
+		username string
+		pair     *config.Pair
+		err      error
 	}
-	errMsg struct {
+	usersLookedUpMsg struct {
+		pairs  []config.Pair
+		failed []config.Pair // users that couldn't be looked up
+		err    error
+	}
+	// pairsChangedMsg reports the result of a confirmed change to the pairs
+	pairsChangedMsg struct {
 		err error
 	}
+	teamLoadedForAddMsg struct {
+		team    github.Team
+		members []config.Pair
+	}
+	// errMsg reports a failed command, which retry runs again while the
+	// user is still looking at scope
+	errMsg struct {
+		err   error
+		retry tea.Cmd
+		scope string
+	}
 	// debounceTickMsg is sent after the debounce delay
 	debounceTickMsg struct {
 		query   string
@@ -126,6 +236,9 @@ type (
 	teamMembersLoadedMsg struct {
 		members []config.Pair
 	}
+	suggestionsLoadedMsg struct {
+		suggestions []history.Suggestion
+	}
 )
 
 // NewModel creates a new TUI model.
@@ -150,35 +263,48 @@ func NewModelWithTheme(themeName string) Model {
 	ti.Width = 40
 
 	// Set up pair list
-	delegate := list.NewDefaultDelegate()
-	pairList := list.New([]list.Item{}, delegate, 0, 0)
+	zones := newZoneMap()
+	pairDelegate := newHighlightDelegate(zones, "pairs")
+	pairDelegate.removable = true
+	pairList := list.New([]list.Item{}, pairDelegate, 0, 0)
 	pairList.Title = "Current Pairs"
 	pairList.SetShowStatusBar(false)
 	pairList.SetFilteringEnabled(false)
 	pairList.Styles.Title = styles.ListTitle
 
 	// Set up search results list
-	searchList := list.New([]list.Item{}, delegate, 0, 0)
+	searchList := list.New([]list.Item{}, newHighlightDelegate(zones, "search"), 0, 0)
 	searchList.Title = "Search Results"
 	searchList.SetShowStatusBar(false)
 	searchList.SetFilteringEnabled(false)
 
 	// Set up team list
-	teamList := list.New([]list.Item{}, delegate, 0, 0)
+	teamList := list.New([]list.Item{}, newHighlightDelegate(zones, "teams"), 0, 0)
 	teamList.Title = "Your Teams"
 	teamList.SetShowStatusBar(false)
 	teamList.SetFilteringEnabled(false)
 
+	// Set up suggestion list
+	suggestionList := list.New([]list.Item{}, newHighlightDelegate(zones, "suggestions"), 0, 0)
+	suggestionList.Title = "Suggested Pairs"
+	suggestionList.SetShowStatusBar(false)
+	suggestionList.SetFilteringEnabled(false)
+
 	return Model{
-		view:        ViewMain,
-		styles:      styles,
-		pairList:    pairList,
-		searchInput: ti,
-		searchList:  searchList,
-		teamList:    teamList,
-		spinner:     s,
-		loading:     true,
-		searchTab:   TabUsers,
+		view:           ViewMain,
+		themeName:      themeName,
+		styles:         styles,
+		keys:           newKeyMap(settings.Load().Keys),
+		profiles:       make(map[string]profileEntry),
+		zones:          zones,
+		pairList:       pairList,
+		searchInput:    ti,
+		searchList:     searchList,
+		teamList:       teamList,
+		suggestionList: suggestionList,
+		spinner:        s,
+		loading:        true,
+		searchTab:      TabUsers,
 	}
 }
 
@@ -194,18 +320,45 @@ func (m Model) Init() tea.Cmd {
 
 // Update handles messages.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+
+	// Fetch the profile of whoever is highlighted now
+	if next, ok := model.(Model); ok {
+		var profileCmd tea.Cmd
+		model, profileCmd = next.requestProfile()
+		cmd = tea.Batch(cmd, profileCmd)
+	}
+	return model, cmd
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		return m.handleKeyMsg(msg)
 
+	case tea.MouseMsg:
+		return m.handleMouseMsg(msg)
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		m.pairList.SetSize(msg.Width-4, msg.Height-8)
-		m.searchList.SetSize(msg.Width-4, msg.Height-12)
-		m.teamList.SetSize(msg.Width-4, msg.Height-12)
+		m.resizeLists()
+		return m, nil
+
+	case profileTickMsg:
+		if m.profilePending == msg.username {
+			m.profilePending = ""
+		}
+		if m.profileCached(msg.username) || m.highlightedUser() != msg.username {
+			return m, nil
+		}
+		m.profiles[msg.username] = profileEntry{loading: true}
+		return m, loadProfile(msg.username)
+
+	case profileLoadedMsg:
+		m.profiles[msg.username] = profileEntry{profile: msg.profile, err: msg.err}
 		return m, nil
 
 	case spinner.TickMsg:
@@ -216,9 +369,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case pairsLoadedMsg:
 		m.pairs = msg.pairs
 		m.recentPairs = msg.recent
+		m.recentPinned = msg.pinned
+		m.branch = msg.branch
 		m.loading = false
 		m.hookInstalled = hook.IsInstalled()
+		m.hookCurrent = hook.IsCurrent()
 		m.updatePairList()
+		if m.view == ViewSearch {
+			m.updateSearchList()
+		}
 		return m, nil
 
 	case collaboratorsLoadedMsg:
@@ -243,33 +402,73 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case userLookedUpMsg:
 		m.loading = false
 		if msg.err != nil {
-			m.err = msg.err
-			return m, nil
+			return m.notifyErr(msg.err, lookupUser(msg.username))
 		}
 		if msg.pair != nil {
 			if err := config.AddPair(*msg.pair); err != nil {
-				m.err = err
-				return m, nil
+				return m.notifyErr(err, nil)
 			}
-			// Reset all search/team state
-			m.view = ViewMain
-			m.searchInput.SetValue("")
-			m.searchResults = nil
-			m.selectedTeam = nil
-			m.teamMembers = nil
-			m.filteredTeamMembers = nil
-			return m, loadPairs
+			m.resetSearch()
+			m, cmd := m.notify(severitySuccess, "Added @"+msg.pair.Username, nil)
+			return m, tea.Batch(cmd, loadPairs)
 		}
 		return m, nil
 
-	case errMsg:
-		m.err = msg.err
+	case usersLookedUpMsg:
 		m.loading = false
+		added, err := config.AddPairs(msg.pairs)
+		if err != nil {
+			return m.notifyErr(err, nil)
+		}
+		// Stay in the view so more people can be picked
+		m.selected = nil
+		if m.view == ViewSearch || m.view == ViewTeamMembers {
+			m.updateSearchList()
+		}
+		var cmd tea.Cmd
+		if added > 0 {
+			m, cmd = m.notify(severitySuccess, fmt.Sprintf("Added %d pairs", added), nil)
+		}
+		if msg.err != nil {
+			// Users that couldn't be looked up can be tried again
+			var errCmd tea.Cmd
+			m, errCmd = m.notifyErr(msg.err, lookupUsers(msg.failed))
+			cmd = tea.Batch(cmd, errCmd)
+		}
+		return m, tea.Batch(cmd, loadPairs)
+
+	case pairsChangedMsg:
+		m.loading = false
+		if msg.err != nil {
+			return m.notifyErr(msg.err, nil)
+		}
+		m, cmd := m.notify(severitySuccess, "Done • press "+firstKey(m.keys.Undo)+" to undo", nil)
+		return m, tea.Batch(cmd, loadPairs)
+
+	case toastExpiredMsg:
+		m.dismissToast(msg.id)
 		return m, nil
 
+	case teamLoadedForAddMsg:
+		m.loading = false
+		var members []config.Pair
+		for _, p := range filterOutUser(msg.members, m.currentUser) {
+			if !slices.ContainsFunc(m.pairs, func(q config.Pair) bool { return q.Username == p.Username }) {
+				members = append(members, p)
+			}
+		}
+		if len(members) == 0 {
+			return m.notify(severityWarning, fmt.Sprintf("Everyone in %s is already a pair", msg.team.Name), nil)
+		}
+		return m.confirm(fmt.Sprintf("Add all %d members of %s?", len(members), msg.team.Name), lookupUsers(members))
+
+	case errMsg:
+		m.loading = false
+		return m.notifyErrIn(msg.scope, msg.err, msg.retry)
+
 	case teamsLoadedMsg:
 		m.teams = msg.teams
-		m.filteredTeams = msg.teams
+		m.filterTeams(m.searchInput.Value())
 		m.loading = false
 		m.updateTeamList()
 		return m, nil
@@ -277,7 +476,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case teamMembersLoadedMsg:
 		filtered := filterOutUser(msg.members, m.currentUser)
 		m.teamMembers = filtered
-		m.filteredTeamMembers = filtered
+		m.filterTeamMembers(m.searchInput.Value())
 		m.loading = false
 		m.updateSearchList()
 		if m.focusInput {
@@ -286,6 +485,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case suggestionsLoadedMsg:
+		m.suggestions = msg.suggestions
+		m.loading = false
+		m.updateSuggestionList()
+		return m, nil
+
 	case debounceTickMsg:
 		// Only trigger search if this is the latest timer and query matches
 		if msg.timerID == m.debounceTimer && msg.query == m.searchInput.Value() {
@@ -315,31 +520,51 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		newValue := m.searchInput.Value()
 		if newValue != oldValue && m.searchInput.Focused() {
 			m.debounceTimer++
+			m.searchResults = nil
+			m.updateSearchList()
 			cmds = append(cmds, scheduleDebounce(newValue, m.debounceTimer))
 		}
 
 		m.searchList, cmd = m.searchList.Update(msg)
 		cmds = append(cmds, cmd)
+	case ViewEdit:
+		for i := range m.editInputs {
+			var cmd tea.Cmd
+			m.editInputs[i], cmd = m.editInputs[i].Update(msg)
+			cmds = append(cmds, cmd)
+		}
 	}
 
 	return m, tea.Batch(cmds...)
 }
 
 func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Global keys
-	switch msg.String() {
-	case "ctrl+c", "q":
+	if m.confirmPrompt != "" && msg.String() != "ctrl+c" {
+		return m.handleConfirmKeys(msg)
+	}
+
+	// Global keys. Quit keys are typed as text while an input has focus.
+	switch {
+	case msg.String() == "ctrl+c", key.Matches(msg, m.keys.Quit) && !m.typing():
 		if m.view == ViewMain {
 			return m, tea.Quit
 		}
-		m.view = ViewMain
-		m.searchInput.SetValue("")
-		m.searchResults = nil
-		m.selectedTeam = nil
-		m.teamMembers = nil
+		if m.view == ViewSettings {
+			m.closeSettings()
+		}
+		m.resetSearch()
 		return m, nil
 
-	case "esc":
+	case key.Matches(msg, m.keys.Back):
+		if m.view == ViewEdit {
+			m.view = ViewMain
+			m.editErr = nil
+			return m, nil
+		}
+		if m.view == ViewSettings {
+			m.closeSettings()
+			return m, nil
+		}
 		if m.view == ViewTeamMembers {
 			m.view = ViewTeams
 			m.selectedTeam = nil
@@ -349,16 +574,27 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		if m.view != ViewMain {
-			m.view = ViewMain
-			m.searchInput.SetValue("")
-			m.searchResults = nil
-			m.selectedTeam = nil
-			m.teamMembers = nil
+			m.resetSearch()
 			return m, nil
 		}
 		return m, tea.Quit
 
-	case "?":
+	case key.Matches(msg, m.keys.Retry) && !m.typing():
+		if _, ok := m.retryable(); ok {
+			return m.retry()
+		}
+
+	case key.Matches(msg, m.keys.NextTab, m.keys.PrevTab):
+		step := SearchTab(1)
+		if key.Matches(msg, m.keys.PrevTab) {
+			step = SearchTab(len(tabNames) - 1)
+		}
+		switch m.view {
+		case ViewSearch, ViewTeams, ViewTeamMembers, ViewSuggestions:
+			return m.switchTab((m.searchTab + step) % SearchTab(len(tabNames)))
+		}
+
+	case key.Matches(msg, m.keys.Help) && !m.typing():
 		if m.view == ViewMain {
 			m.view = ViewHelp
 			return m, nil
@@ -379,8 +615,14 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleTeamsKeys(msg)
 	case ViewTeamMembers:
 		return m.handleTeamMembersKeys(msg)
+	case ViewSuggestions:
+		return m.handleSuggestionsKeys(msg)
+	case ViewEdit:
+		return m.handleEditKeys(msg)
+	case ViewSettings:
+		return m.handleSettingsKeys(msg)
 	case ViewHelp:
-		if msg.String() == "enter" || msg.String() == "esc" || msg.String() == "?" {
+		if key.Matches(msg, m.keys.Confirm, m.keys.Back, m.keys.Help) {
 			m.view = ViewMain
 			return m, nil
 		}
@@ -390,53 +632,73 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleMainKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "a", "/":
-		m.view = ViewSearch
-		m.searchTab = TabUsers
-		m.searchInput.Focus()
-		m.searchInput.SetValue("")
-		m.searchInput.Placeholder = "Search GitHub users..."
-		m.searchResults = nil
-		m.lastQuery = ""
-		m.err = nil
-		m.updateSearchList() // Show recent/collaborators initially
-		return m, nil
+	switch {
+	case key.Matches(msg, m.keys.Search):
+		return m.switchTab(TabUsers)
 
-	case "t":
-		m.view = ViewTeams
-		m.loading = true
-		m.err = nil
-		m.searchInput.SetValue("")
-		m.searchInput.Placeholder = "Filter teams..."
-		m.searchInput.Focus()
-		return m, loadTeams
+	case key.Matches(msg, m.keys.Teams):
+		return m.switchTab(TabTeams)
+
+	case key.Matches(msg, m.keys.Suggest):
+		return m.switchTab(TabSuggestions)
 
-	case "d", "backspace", "delete":
+	case key.Matches(msg, m.keys.Settings):
+		return m.openSettings()
+
+	case key.Matches(msg, m.keys.Remove):
 		if item, ok := m.pairList.SelectedItem().(pairItem); ok {
-			if err := config.RemovePair(item.pair.Username); err != nil {
-				m.err = err
-				return m, nil
-			}
-			return m, loadPairs
+			return m.confirmRemove(item.pair.Username)
 		}
 
-	case "c":
-		if err := config.ClearPairs(); err != nil {
-			m.err = err
-			return m, nil
+	case key.Matches(msg, m.keys.Edit):
+		if item, ok := m.pairList.SelectedItem().(pairItem); ok {
+			return m.startEdit(item.pair)
 		}
-		return m, loadPairs
 
-	case "i":
-		if !m.hookInstalled {
+	case key.Matches(msg, m.keys.Clear):
+		if len(m.pairs) > 0 {
+			return m.confirm(fmt.Sprintf("Clear all %d pairs?", len(m.pairs)), changePairs(config.ClearPairs))
+		}
+
+	case key.Matches(msg, m.keys.Undo):
+		entry, err := config.Undo()
+		if errors.Is(err, config.ErrNothingToUndo) {
+			return m.notify(severityWarning, err.Error(), nil)
+		}
+		if err != nil {
+			return m.notifyErr(err, nil)
+		}
+		m, cmd := m.notify(severitySuccess, "Undid: "+entry.Action, nil)
+		return m, tea.Batch(cmd, loadPairs)
+
+	case key.Matches(msg, m.keys.Redo):
+		entry, err := config.Redo()
+		if errors.Is(err, config.ErrNothingToRedo) {
+			return m.notify(severityWarning, err.Error(), nil)
+		}
+		if err != nil {
+			return m.notifyErr(err, nil)
+		}
+		m, cmd := m.notify(severitySuccess, "Redid: "+entry.Action, nil)
+		return m, tea.Batch(cmd, loadPairs)
+
+	case key.Matches(msg, m.keys.InstallHook):
+		if !m.hookInstalled || !m.hookCurrent {
 			if err := hook.Install(); err != nil {
-				m.err = err
-				return m, nil
+				return m.notifyErr(err, nil)
+			}
+			message := "Installed git hook"
+			if m.hookInstalled {
+				message = "Updated git hook"
 			}
-			m.hookInstalled = true
+			m.hookInstalled, m.hookCurrent = true, true
+			return m.notify(severitySuccess, message, nil)
 		}
 		return m, nil
+
+	case key.Matches(msg, m.keys.Up, m.keys.Down):
+		m.moveCursor(&m.pairList, msg)
+		return m, nil
 	}
 
 	var cmd tea.Cmd
@@ -444,9 +706,115 @@ func (m Model) handleMainKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-func (m Model) handleSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+// moveCursor moves a list's cursor for the up and down bindings. The
+// lists' own key maps only know their default keys.
+func (m Model) moveCursor(l *list.Model, msg tea.KeyMsg) {
+	if key.Matches(msg, m.keys.Up) {
+		l.CursorUp()
+	} else {
+		l.CursorDown()
+	}
+}
+
+// typing reports whether keys are going to a text input.
+func (m Model) typing() bool {
+	switch m.view {
+	case ViewEdit:
+		return true
+	case ViewSearch, ViewTeams, ViewTeamMembers:
+		return m.searchInput.Focused()
+	}
+	return false
+}
+
+// confirmRemove asks before removing a pair.
+func (m Model) confirmRemove(username string) (tea.Model, tea.Cmd) {
+	return m.confirm("Remove @"+username+"?", changePairs(func() error {
+		return config.RemovePair(username)
+	}))
+}
+
+// confirm asks the user to confirm an action before running it.
+func (m Model) confirm(prompt string, action tea.Cmd) (tea.Model, tea.Cmd) {
+	m.confirmPrompt = prompt
+	m.confirmAction = action
+	return m, nil
+}
+
+func (m Model) handleConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	action := m.confirmAction
+	m.confirmPrompt = ""
+	m.confirmAction = nil
+
 	switch msg.String() {
-	case "enter":
+	case "y", "Y", "enter":
+		m.loading = true
+		return m, action
+	}
+
+	// Anything else cancels
+	return m, nil
+}
+
+// startEdit opens the edit form for a pair.
+func (m Model) startEdit(pair config.Pair) (tea.Model, tea.Cmd) {
+	name := textinput.New()
+	name.Prompt = "Name:  "
+	name.CharLimit = 100
+	name.Width = 40
+	name.SetValue(pair.Name)
+
+	email := textinput.New()
+	email.Prompt = "Email: "
+	email.CharLimit = 100
+	email.Width = 40
+	email.SetValue(pair.Email)
+
+	m.view = ViewEdit
+	m.editing = pair
+	m.editInputs = []textinput.Model{name, email}
+	m.editFocus = 0
+	m.editErr = nil
+	return m, m.editInputs[0].Focus()
+}
+
+func (m Model) handleEditKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Keys that type a character always go to the input, even if bound
+	next := key.Matches(msg, m.keys.Focus, m.keys.Down) && msg.Type != tea.KeyRunes
+	prev := key.Matches(msg, m.keys.PrevTab, m.keys.Up) && msg.Type != tea.KeyRunes
+
+	switch {
+	case next, prev:
+		m.editInputs[m.editFocus].Blur()
+		if next {
+			m.editFocus = (m.editFocus + 1) % len(m.editInputs)
+		} else {
+			m.editFocus = (m.editFocus + len(m.editInputs) - 1) % len(m.editInputs)
+		}
+		return m, m.editInputs[m.editFocus].Focus()
+
+	case key.Matches(msg, m.keys.Confirm):
+		pair := m.editing
+		pair.Name = strings.TrimSpace(m.editInputs[0].Value())
+		pair.Email = strings.TrimSpace(m.editInputs[1].Value())
+		if err := config.EditPair(pair); err != nil {
+			m.editErr = err
+			return m, nil
+		}
+		m.view = ViewMain
+		m.editErr = nil
+		m, cmd := m.notify(severitySuccess, "Saved @"+pair.Username, nil)
+		return m, tea.Batch(cmd, loadPairs)
+	}
+
+	var cmd tea.Cmd
+	m.editInputs[m.editFocus], cmd = m.editInputs[m.editFocus].Update(msg)
+	return m, cmd
+}
+
+func (m Model) handleSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Confirm):
 		if m.searchInput.Focused() {
 			query := strings.TrimSpace(m.searchInput.Value())
 			if query != "" {
@@ -460,14 +828,14 @@ func (m Model) handleSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return m, searchUsers(query)
 			}
 		} else {
-			// Select from search list - fetch full details first
-			if item, ok := m.searchList.SelectedItem().(pairItem); ok {
-				m.loading = true
-				return m, lookupUser(item.pair.Username)
-			}
+			return m.addHighlighted()
 		}
 
-	case "tab":
+	case key.Matches(msg, m.keys.Select) && !m.searchInput.Focused():
+		m.toggleSelected()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Focus):
 		if m.searchInput.Focused() {
 			m.searchInput.Blur()
 		} else {
@@ -475,11 +843,31 @@ func (m Model) handleSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case "up", "down":
+	case key.Matches(msg, m.keys.Up, m.keys.Down) && !m.searchInput.Focused():
+		m.moveCursor(&m.searchList, msg)
+		return m, nil
+
+	case key.Matches(msg, m.keys.Pin, m.keys.Forget):
+		// Pin or forget recent pairs while the list has focus
 		if !m.searchInput.Focused() {
-			var cmd tea.Cmd
-			m.searchList, cmd = m.searchList.Update(msg)
-			return m, cmd
+			item, ok := m.searchList.SelectedItem().(pairItem)
+			if !ok || !item.recent {
+				return m, nil
+			}
+
+			var err error
+			switch {
+			case key.Matches(msg, m.keys.Forget):
+				_, err = config.RemoveRecent(item.pair.Username)
+			case item.pinned:
+				err = config.UnpinRecent(item.pair.Username)
+			default:
+				err = config.PinRecent(item.pair)
+			}
+			if err != nil {
+				return m.notifyErr(err, nil)
+			}
+			return m, loadPairs
 		}
 	}
 
@@ -492,11 +880,12 @@ func (m Model) handleSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	newValue := m.searchInput.Value()
 	if newValue != oldValue && m.searchInput.Focused() {
 		m.debounceTimer++
+		// Filter recent pairs and collaborators locally straight away,
+		// the GitHub search waits for typing to pause
+		m.searchResults = nil
+		m.updateSearchList()
 		if newValue == "" {
-			// Clear results immediately when input is empty
-			m.searchResults = nil
 			m.lastQuery = ""
-			m.updateSearchList()
 			return m, cmd
 		}
 		return m, tea.Batch(cmd, scheduleDebounce(newValue, m.debounceTimer))
@@ -505,20 +894,75 @@ func (m Model) handleSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// switchTab opens the view for a tab, resetting any shared search state.
+func (m Model) switchTab(tab SearchTab) (tea.Model, tea.Cmd) {
+	m.searchTab = tab
+	m.searchInput.SetValue("")
+	m.selectedTeam = nil
+	m.teamMembers = nil
+	m.filteredTeamMembers = nil
+	m.memberMatches = nil
+
+	switch tab {
+	case TabTeams:
+		m.view = ViewTeams
+		m.loading = true
+		m.searchInput.Placeholder = "Filter teams..."
+		m.searchInput.Focus()
+		return m, loadTeams
+
+	case TabSuggestions:
+		m.view = ViewSuggestions
+		m.loading = true
+		m.searchInput.Blur()
+		return m, loadSuggestions(m.currentUser)
+
+	default:
+		m.view = ViewSearch
+		m.searchInput.Focus()
+		m.searchInput.Placeholder = "Search GitHub users..."
+		m.searchResults = nil
+		m.lastQuery = ""
+		m.updateSearchList() // Show recent/collaborators initially
+		return m, nil
+	}
+}
+
+func (m Model) handleSuggestionsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Confirm):
+		if item, ok := m.suggestionList.SelectedItem().(suggestionItem); ok {
+			m.loading = true
+			return m, lookupUser(item.suggestion.Pair.Username)
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up, m.keys.Down):
+		m.moveCursor(&m.suggestionList, msg)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.suggestionList, cmd = m.suggestionList.Update(msg)
+	return m, cmd
+}
+
 func (m Model) handleTeamsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
+	switch {
+	case key.Matches(msg, m.keys.Confirm):
+		if item, ok := m.teamList.SelectedItem().(teamItem); ok {
+			return m.openTeam(item.team)
+		}
+
+	case key.Matches(msg, m.keys.AddTeam) && !m.searchInput.Focused():
+		// Add the whole highlighted team while the list has focus
 		if item, ok := m.teamList.SelectedItem().(teamItem); ok {
-			m.selectedTeam = &item.team
-			m.view = ViewTeamMembers
 			m.loading = true
-			m.focusInput = true
-			m.searchInput.SetValue("")
-			m.searchInput.Placeholder = "Filter team members..."
-			return m, loadTeamMembers(item.team.Org, item.team.Slug)
+			return m, loadTeamForAdd(item.team)
 		}
+		return m, nil
 
-	case "tab":
+	case key.Matches(msg, m.keys.Focus):
 		if m.searchInput.Focused() {
 			m.searchInput.Blur()
 		} else {
@@ -526,10 +970,9 @@ func (m Model) handleTeamsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case "up", "down":
-		var cmd tea.Cmd
-		m.teamList, cmd = m.teamList.Update(msg)
-		return m, cmd
+	case key.Matches(msg, m.keys.Up, m.keys.Down):
+		m.moveCursor(&m.teamList, msg)
+		return m, nil
 	}
 
 	// Update text input and filter teams
@@ -546,23 +989,49 @@ func (m Model) handleTeamsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// openTeam shows a team's members.
+func (m Model) openTeam(team github.Team) (tea.Model, tea.Cmd) {
+	m.selectedTeam = &team
+	m.view = ViewTeamMembers
+	m.loading = true
+	m.focusInput = true
+	m.searchInput.SetValue("")
+	m.searchInput.Placeholder = "Filter team members..."
+	return m, loadTeamMembers(team.Org, team.Slug)
+}
+
+// addHighlighted adds the users selected in the search list, or the
+// highlighted one, fetching their full details first.
+func (m Model) addHighlighted() (tea.Model, tea.Cmd) {
+	if len(m.selected) > 0 {
+		m.loading = true
+		return m, lookupUsers(m.selected)
+	}
+	if item, ok := m.searchList.SelectedItem().(pairItem); ok {
+		m.loading = true
+		return m, lookupUser(item.pair.Username)
+	}
+	return m, nil
+}
+
 func (m Model) handleTeamMembersKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "enter":
+	switch {
+	case key.Matches(msg, m.keys.Confirm):
 		// If input is focused and empty, blur it to allow list navigation
 		if m.searchInput.Focused() && m.searchInput.Value() == "" {
 			m.searchInput.Blur()
 			return m, nil
 		}
-		// If input not focused, select from list - fetch full details first
+		// If input not focused, select from list
 		if !m.searchInput.Focused() {
-			if item, ok := m.searchList.SelectedItem().(pairItem); ok {
-				m.loading = true
-				return m, lookupUser(item.pair.Username)
-			}
+			return m.addHighlighted()
 		}
 
-	case "tab":
+	case key.Matches(msg, m.keys.Select) && !m.searchInput.Focused():
+		m.toggleSelected()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Focus):
 		if m.searchInput.Focused() {
 			m.searchInput.Blur()
 		} else {
@@ -570,11 +1039,10 @@ func (m Model) handleTeamMembersKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case "up", "down":
+	case key.Matches(msg, m.keys.Up, m.keys.Down):
 		// Always allow arrow key navigation in the list
-		var cmd tea.Cmd
-		m.searchList, cmd = m.searchList.Update(msg)
-		return m, cmd
+		m.moveCursor(&m.searchList, msg)
+		return m, nil
 	}
 
 	// Update text input and filter team members
@@ -591,12 +1059,53 @@ func (m Model) handleTeamMembersKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// resetSearch returns to the main view, clearing search, team and
+// selection state.
+func (m *Model) resetSearch() {
+	m.view = ViewMain
+	m.searchInput.SetValue("")
+	m.searchResults = nil
+	m.selectedTeam = nil
+	m.teamMembers = nil
+	m.filteredTeamMembers = nil
+	m.memberMatches = nil
+	m.selected = nil
+}
+
+// toggleSelected marks or unmarks the highlighted user to be added.
+func (m *Model) toggleSelected() {
+	item, ok := m.searchList.SelectedItem().(pairItem)
+	if !ok {
+		return
+	}
+
+	i := slices.IndexFunc(m.selected, func(p config.Pair) bool { return p.Username == item.pair.Username })
+	if i >= 0 {
+		m.selected = slices.Delete(m.selected, i, i+1)
+	} else {
+		m.selected = append(m.selected, item.pair)
+	}
+
+	item.selected = i < 0
+	m.searchList.SetItem(m.searchList.Index(), item)
+}
+
+// isSelected reports whether a user is marked to be added.
+func (m *Model) isSelected(username string) bool {
+	return slices.ContainsFunc(m.selected, func(p config.Pair) bool { return p.Username == username })
+}
+
 func (m *Model) updatePairList() {
 	items := make([]list.Item, len(m.pairs))
 	for i, p := range m.pairs {
 		items[i] = pairItem{pair: p}
 	}
 	m.pairList.SetItems(items)
+
+	m.pairList.Title = "Current Pairs"
+	if m.branch != "" {
+		m.pairList.Title += " on " + m.branch
+	}
 }
 
 func (m *Model) updateSearchList() {
@@ -604,36 +1113,54 @@ func (m *Model) updateSearchList() {
 
 	// If viewing team members, show filtered team members
 	if m.view == ViewTeamMembers {
-		for _, p := range m.filteredTeamMembers {
-			items = append(items, pairItem{pair: p})
+		for i, p := range m.filteredTeamMembers {
+			items = append(items, pairItem{pair: p, selected: m.isSelected(p.Username), matches: m.memberMatches[i]})
 		}
 		m.searchList.SetItems(items)
 		return
 	}
 
-	if len(m.searchResults) > 0 {
-		for _, p := range m.searchResults {
-			items = append(items, pairItem{pair: p})
+	// Recent pairs and collaborators matching the query come first, so they
+	// show while typing before any GitHub search returns
+	seen := make(map[string]bool)
+	for _, p := range m.pairs {
+		seen[p.Username] = true
+	}
+
+	var local []config.Pair
+	recent := make(map[string]bool)
+	for _, p := range m.recentPairs {
+		if !seen[p.Username] {
+			local = append(local, p)
+			recent[p.Username] = true
+			seen[p.Username] = true
 		}
-	} else {
-		// Show recent pairs and collaborators
-		seen := make(map[string]bool)
-		for _, p := range m.pairs {
+	}
+	for _, p := range m.collaborators {
+		if !seen[p.Username] {
+			local = append(local, p)
 			seen[p.Username] = true
 		}
+	}
 
-		for _, p := range m.recentPairs {
-			if !seen[p.Username] {
-				items = append(items, pairItem{pair: p})
-				seen[p.Username] = true
-			}
-		}
+	shown := make(map[string]bool)
+
+	matched, matches := fuzzyPairs(m.searchInput.Value(), local)
+	for i, p := range matched {
+		items = append(items, pairItem{
+			pair:     p,
+			recent:   recent[p.Username],
+			pinned:   recent[p.Username] && slices.Contains(m.recentPinned, p.Username),
+			selected: m.isSelected(p.Username),
+			matches:  matches[i],
+		})
+		shown[p.Username] = true
+	}
 
-		for _, p := range m.collaborators {
-			if !seen[p.Username] {
-				items = append(items, pairItem{pair: p})
-				seen[p.Username] = true
-			}
+	for _, p := range m.searchResults {
+		if !shown[p.Username] {
+			items = append(items, pairItem{pair: p, selected: m.isSelected(p.Username)})
+			shown[p.Username] = true
 		}
 	}
 
@@ -643,54 +1170,54 @@ func (m *Model) updateSearchList() {
 func (m *Model) updateTeamList() {
 	items := make([]list.Item, len(m.filteredTeams))
 	for i, t := range m.filteredTeams {
-		items[i] = teamItem{team: t}
+		items[i] = teamItem{team: t, matches: m.teamMatches[i]}
 	}
 	m.teamList.SetItems(items)
 }
 
-func (m *Model) filterTeams(query string) {
-	if query == "" {
-		m.filteredTeams = m.teams
-		return
+func (m *Model) updateSuggestionList() {
+	now := time.Now()
+	current := make(map[string]bool)
+	for _, p := range m.pairs {
+		current[p.Username] = true
 	}
 
-	query = strings.ToLower(query)
-	filtered := make([]github.Team, 0)
-	for _, t := range m.teams {
-		if strings.Contains(strings.ToLower(t.Name), query) ||
-			strings.Contains(strings.ToLower(t.Slug), query) ||
-			strings.Contains(strings.ToLower(t.Org), query) {
-			filtered = append(filtered, t)
+	var items []list.Item
+	for _, s := range m.suggestions {
+		if !current[s.Pair.Username] {
+			items = append(items, suggestionItem{suggestion: s, now: now})
 		}
 	}
-	m.filteredTeams = filtered
+	m.suggestionList.SetItems(items)
 }
 
-func (m *Model) filterTeamMembers(query string) {
-	if query == "" {
-		m.filteredTeamMembers = m.teamMembers
-		return
-	}
+// filterTeams fuzzy matches teams by name and org/slug, best first.
+func (m *Model) filterTeams(query string) {
+	m.filteredTeams, m.teamMatches = fuzzyTeams(query, m.teams)
+}
 
-	query = strings.ToLower(query)
-	filtered := make([]config.Pair, 0)
-	for _, p := range m.teamMembers {
-		if strings.Contains(strings.ToLower(p.Username), query) ||
-			strings.Contains(strings.ToLower(p.Name), query) {
-			filtered = append(filtered, p)
-		}
-	}
-	m.filteredTeamMembers = filtered
+// filterTeamMembers fuzzy matches team members by username and name, best
+// first.
+func (m *Model) filterTeamMembers(query string) {
+	m.filteredTeamMembers, m.memberMatches = fuzzyPairs(query, m.teamMembers)
 }
 
 // Commands
 func loadPairs() tea.Msg {
 	pairs, err := config.LoadPairs()
 	if err != nil {
-		return errMsg{err: err}
+		return errMsg{err: err, retry: loadPairs}
+	}
+	recent, err := config.LoadRecent()
+	if err != nil {
+		return errMsg{err: err, retry: loadPairs}
+	}
+	return pairsLoadedMsg{
+		pairs:  pairs.Pairs,
+		recent: recent.Recent,
+		pinned: recent.Pinned,
+		branch: config.ActiveBranch(),
 	}
-	recent, _ := config.LoadRecent()
-	return pairsLoadedMsg{pairs: pairs.Pairs, recent: recent.Recent}
 }
 
 func loadCollaborators() tea.Msg {
@@ -702,16 +1229,49 @@ func searchUsers(query string) tea.Cmd {
 	return func() tea.Msg {
 		results, err := github.SearchUsers(query)
 		if err != nil {
-			return errMsg{err: err}
+			return errMsg{err: err, retry: searchUsers(query), scope: searchScope(query)}
 		}
 		return searchResultsMsg{results: results, query: query}
 	}
 }
 
+// lookupUsers fetches the full details of several users at once.
+func lookupUsers(users []config.Pair) tea.Cmd {
+	usernames := make([]string, len(users))
+	for i, u := range users {
+		usernames[i] = u.Username
+	}
+
+	return func() tea.Msg {
+		pairs := make([]*config.Pair, len(usernames))
+		errs := make([]error, len(usernames))
+
+		var wg sync.WaitGroup
+		for i, username := range usernames {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				pairs[i], errs[i] = github.LookupUser(username)
+			}()
+		}
+		wg.Wait()
+
+		var found, failed []config.Pair
+		for i, p := range pairs {
+			if p != nil {
+				found = append(found, *p)
+			} else {
+				failed = append(failed, users[i])
+			}
+		}
+		return usersLookedUpMsg{pairs: found, failed: failed, err: errors.Join(errs...)}
+	}
+}
+
 func lookupUser(username string) tea.Cmd {
 	return func() tea.Msg {
 		pair, err := github.LookupUser(username)
-		return userLookedUpMsg{pair: pair, err: err}
+		return userLookedUpMsg{username: username, pair: pair, err: err}
 	}
 }
 
@@ -724,21 +1284,56 @@ func scheduleDebounce(query string, timerID int) tea.Cmd {
 func loadTeams() tea.Msg {
 	teams, err := github.GetUserTeams()
 	if err != nil {
-		return errMsg{err: err}
+		return errMsg{err: err, retry: loadTeams, scope: teamsScope}
 	}
 	return teamsLoadedMsg{teams: teams}
 }
 
+// loadTeamForAdd fetches a team's members so they can all be added.
+func loadTeamForAdd(team github.Team) tea.Cmd {
+	return func() tea.Msg {
+		members, err := github.GetTeamMembers(team.Org, team.Slug)
+		if err != nil {
+			return errMsg{err: err, retry: loadTeamForAdd(team), scope: teamsScope}
+		}
+		return teamLoadedForAddMsg{team: team, members: members}
+	}
+}
+
+// changePairs runs a change to the pairs as a command.
+func changePairs(change func() error) tea.Cmd {
+	return func() tea.Msg {
+		return pairsChangedMsg{err: change()}
+	}
+}
+
 func loadTeamMembers(org, slug string) tea.Cmd {
 	return func() tea.Msg {
 		members, err := github.GetTeamMembers(org, slug)
 		if err != nil {
-			return errMsg{err: err}
+			return errMsg{err: err, retry: loadTeamMembers(org, slug), scope: teamScope(org, slug)}
 		}
 		return teamMembersLoadedMsg{members: members}
 	}
 }
 
+func loadSuggestions(currentUser string) tea.Cmd {
+	return func() tea.Msg {
+		roster, err := github.GetTeammates("")
+		if err != nil {
+			return errMsg{err: err, retry: loadSuggestions(currentUser), scope: suggestionsScope}
+		}
+		if currentUser == "" {
+			currentUser, _ = github.GetAuthenticatedUser()
+		}
+		suggestions, err := history.SuggestFromRoster(roster, currentUser)
+		if err != nil {
+			return errMsg{err: err, retry: loadSuggestions(currentUser), scope: suggestionsScope}
+		}
+		return suggestionsLoadedMsg{suggestions: suggestions}
+	}
+}
+
 func loadCurrentUser() tea.Msg {
 	username, _ := github.GetAuthenticatedUser()
 	return currentUserLoadedMsg{username: username}
@@ -0,0 +1,123 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleMouseMsg scrolls lists with the wheel and handles clicks on the
+// zones marked in the view. Clicking an item highlights it, and clicking
+// the highlighted item again acts as Enter.
+func (m Model) handleMouseMsg(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.confirmPrompt != "" || msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		if l := m.activeList(); l != nil {
+			l.CursorUp()
+		}
+		return m, nil
+	case tea.MouseButtonWheelDown:
+		if l := m.activeList(); l != nil {
+			l.CursorDown()
+		}
+		return m, nil
+	case tea.MouseButtonLeft:
+	default:
+		return m, nil
+	}
+
+	zone, ok := m.zones.at(msg.X, msg.Y)
+	if !ok {
+		return m, nil
+	}
+	kind, arg, _ := strings.Cut(zone, ":")
+	index, err := strconv.Atoi(arg)
+	if err != nil {
+		return m, nil
+	}
+
+	switch kind {
+	case "tab":
+		return m.switchTab(SearchTab(index))
+
+	case "remove":
+		if m.view != ViewMain {
+			break
+		}
+		m.pairList.Select(index)
+		if item, ok := m.pairList.SelectedItem().(pairItem); ok {
+			return m.confirmRemove(item.pair.Username)
+		}
+
+	case "pairs":
+		if m.view == ViewMain {
+			m.pairList.Select(index)
+		}
+
+	case "search":
+		if m.view != ViewSearch && m.view != ViewTeamMembers {
+			break
+		}
+		again := index == m.searchList.Index() && !m.searchInput.Focused()
+		m.searchInput.Blur()
+		m.searchList.Select(index)
+		if again {
+			return m.addHighlighted()
+		}
+
+	case "teams":
+		if m.view != ViewTeams {
+			break
+		}
+		again := index == m.teamList.Index() && !m.searchInput.Focused()
+		m.searchInput.Blur()
+		m.teamList.Select(index)
+		if item, ok := m.teamList.SelectedItem().(teamItem); ok && again {
+			return m.openTeam(item.team)
+		}
+
+	case "settings":
+		if m.view != ViewSettings {
+			break
+		}
+		again := settingField(index) == m.settings.focus
+		m.settings.focus = settingField(index)
+		if again {
+			m.changeSetting(1)
+		}
+
+	case "suggestions":
+		if m.view != ViewSuggestions {
+			break
+		}
+		again := index == m.suggestionList.Index()
+		m.suggestionList.Select(index)
+		if item, ok := m.suggestionList.SelectedItem().(suggestionItem); ok && again {
+			m.loading = true
+			return m, lookupUser(item.suggestion.Pair.Username)
+		}
+	}
+
+	return m, nil
+}
+
+// activeList returns the list shown in the current view, if any.
+func (m *Model) activeList() *list.Model {
+	switch m.view {
+	case ViewMain:
+		return &m.pairList
+	case ViewSearch, ViewTeamMembers:
+		return &m.searchList
+	case ViewTeams:
+		return &m.teamList
+	case ViewSuggestions:
+		return &m.suggestionList
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/omgitsads/gh-pair/internal/github"
+)
+
+const (
+	// profileDelay waits for the highlight to settle before fetching, so
+	// scrolling through a list doesn't fetch every user passed.
+	profileDelay = 250 * time.Millisecond
+
+	// profilePaneWidth is the width of the profile pane beside a list.
+	profilePaneWidth = 38
+
+	// profilePaneMinWidth is the narrowest view showing the pane beside the
+	// list rather than a summary below it.
+	profilePaneMinWidth = 100
+)
+
+// profileEntry caches a user's profile, or why it couldn't be fetched. An
+// entry with an error is kept while the user stays highlighted, and fetched
+// again the next time the highlight moves to them.
+type profileEntry struct {
+	profile *github.Profile
+	err     error
+	loading bool
+}
+
+type (
+	// profileTickMsg is sent once the highlight has settled on a user
+	profileTickMsg struct {
+		username string
+	}
+
+	profileLoadedMsg struct {
+		username string
+		profile  *github.Profile
+		err      error
+	}
+)
+
+// highlightedUser returns the user highlighted in a view with a profile
+// pane, if any.
+func (m Model) highlightedUser() string {
+	switch m.view {
+	case ViewSearch, ViewTeamMembers:
+		if item, ok := m.searchList.SelectedItem().(pairItem); ok {
+			return item.pair.Username
+		}
+	case ViewSuggestions:
+		if item, ok := m.suggestionList.SelectedItem().(suggestionItem); ok {
+			return item.suggestion.Pair.Username
+		}
+	}
+	return ""
+}
+
+// requestProfile schedules fetching the highlighted user's profile unless it
+// is cached or already scheduled.
+func (m Model) requestProfile() (Model, tea.Cmd) {
+	username := m.highlightedUser()
+	if username != m.profileShown {
+		// Coming back to a user whose profile failed tries it again
+		if entry, ok := m.profiles[username]; ok && entry.err != nil {
+			delete(m.profiles, username)
+		}
+		m.profileShown = username
+	}
+	if username == "" || username == m.profilePending {
+		return m, nil
+	}
+	if m.profileCached(username) {
+		return m, nil
+	}
+
+	m.profilePending = username
+	return m, tea.Tick(profileDelay, func(time.Time) tea.Msg {
+		return profileTickMsg{username: username}
+	})
+}
+
+// profileCached reports whether a user's profile is loaded, loading or
+// failed.
+func (m Model) profileCached(username string) bool {
+	_, ok := m.profiles[username]
+	return ok
+}
+
+func loadProfile(username string) tea.Cmd {
+	return func() tea.Msg {
+		profile, err := github.GetUserProfile(username)
+		return profileLoadedMsg{username: username, profile: profile, err: err}
+	}
+}
+
+// wideProfile reports whether the profile pane fits beside the lists.
+func (m Model) wideProfile() bool {
+	return m.contentWidth() >= profilePaneMinWidth
+}
+
+// withProfile places the highlighted user's profile beside a list on wide
+// terminals, or a one line summary below it on narrow ones.
+func (m Model) withProfile(list string) string {
+	username := m.highlightedUser()
+	if username == "" {
+		return list
+	}
+	if m.wideProfile() {
+		return lipgloss.JoinHorizontal(lipgloss.Top, list, m.profilePane(username))
+	}
+	return list + "\n" + m.profileSummary(username)
+}
+
+// profilePane renders the full profile of a user.
+func (m Model) profilePane(username string) string {
+	width := profilePaneWidth - 6 // border and padding
+	var b strings.Builder
+
+	entry, ok := m.profiles[username]
+	switch {
+	case !ok || entry.loading:
+		b.WriteString(m.styles.HelpKey.Render("@" + username))
+		b.WriteString("\n\n")
+		b.WriteString(m.spinner.View() + m.styles.Dim.Render(" Loading profile..."))
+
+	case entry.err != nil:
+		b.WriteString(m.styles.HelpKey.Render("@" + username))
+		b.WriteString("\n\n")
+		b.WriteString(m.styles.Dim.Render("Profile unavailable"))
+
+	default:
+		p := entry.profile
+		b.WriteString(m.styles.HelpKey.Render("@" + p.Username))
+		if p.Name != "" {
+			b.WriteString("\n" + p.Name)
+		}
+		b.WriteString("\n")
+
+		for _, line := range []struct{ label, value string }{
+			{"Company", p.Company},
+			{"Location", p.Location},
+			{"Orgs", strings.Join(p.Orgs, ", ")},
+		} {
+			if line.value != "" {
+				b.WriteString("\n" + m.styles.Dim.Render(fmt.Sprintf("%-9s", line.label)) + line.value)
+			}
+		}
+		if p.Bio != "" {
+			b.WriteString("\n\n" + lipgloss.NewStyle().Width(width).Render(p.Bio))
+		}
+
+		switch p.Committed {
+		case github.CommitsFound:
+			b.WriteString("\n\n" + m.styles.Success.Render("✓ Has committed to this repo"))
+		case github.CommitsNone:
+			b.WriteString("\n\n" + m.styles.Dim.Render("No commits to this repo"))
+		}
+	}
+
+	return m.styles.Box.Width(profilePaneWidth - 2).Render(b.String())
+}
+
+// profileSummary renders a user's profile on one line.
+func (m Model) profileSummary(username string) string {
+	entry, ok := m.profiles[username]
+	if !ok || entry.loading {
+		return m.styles.Dim.Render("Loading profile...")
+	}
+	if entry.err != nil {
+		return m.styles.Dim.Render("Profile unavailable")
+	}
+
+	p := entry.profile
+	var parts []string
+	for _, s := range []string{p.Company, p.Location, strings.Join(p.Orgs, ", ")} {
+		if s != "" {
+			parts = append(parts, m.styles.Dim.Render(s))
+		}
+	}
+	if p.Committed == github.CommitsFound {
+		parts = append(parts, m.styles.Success.Render("✓ committed here"))
+	}
+	if len(parts) == 0 {
+		return m.styles.Dim.Render("No public profile details")
+	}
+	return strings.Join(parts, m.styles.Dim.Render(" · "))
+}
@@ -0,0 +1,273 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/omgitsads/gh-pair/internal/config"
+	"github.com/omgitsads/gh-pair/internal/hook"
+	"github.com/omgitsads/gh-pair/internal/settings"
+	"github.com/omgitsads/gh-pair/internal/theme"
+)
+
+// settingField is a row of the settings view.
+type settingField int
+
+const (
+	fieldTheme settingField = iota
+	fieldExpiry
+	fieldRecent
+	fieldStorage
+	fieldHook
+	fieldReminder
+	fieldCount
+)
+
+// expiryOptions are the session expiry choices, "" meaning never.
+var expiryOptions = []string{"", "1h", "4h", "8h", "12h", "24h", "168h"}
+
+// maxRecentSize is the largest recent list the settings view offers.
+const maxRecentSize = 50
+
+// storageOption is a pairs backend and its git config scope.
+type storageOption struct {
+	name, scope, label string
+}
+
+var storageOptions = []storageOption{
+	{config.StorageJSON, "", "json file (repo)"},
+	{config.StorageGitConfig, "local", "git config (repo)"},
+	{config.StorageGitConfig, "worktree", "git config (worktree)"},
+	{config.StorageGitConfig, "global", "git config (global)"},
+}
+
+// settingsForm holds the settings being edited, applied only when saved.
+type settingsForm struct {
+	cfg      settings.Config
+	storage  int // index into storageOptions; the backend is per repository
+	themes   []string
+	hook     bool // whether the hooks should be installed in this repo
+	focus    settingField
+	original string // theme to restore if the changes are discarded
+}
+
+// openSettings shows the settings view, starting from the saved settings
+// and the theme in use.
+func (m Model) openSettings() (tea.Model, tea.Cmd) {
+	cfg := settings.Load()
+	cfg.Theme = m.themeName
+	if cfg.RecentSize < 1 {
+		cfg.RecentSize = config.DefaultRecentSize
+	}
+
+	themes := append(theme.PresetNames(), theme.ListCustomThemes()...)
+	if !slices.Contains(themes, cfg.Theme) {
+		themes = append(themes, cfg.Theme)
+	}
+
+	m.view = ViewSettings
+	m.settings = settingsForm{
+		cfg:      cfg,
+		storage:  storageIndex(config.CurrentStorage()),
+		themes:   themes,
+		hook:     m.hookInstalled,
+		original: m.themeName,
+	}
+	return m, nil
+}
+
+// applyTheme restyles the TUI, so themes can be previewed as they're chosen.
+func (m *Model) applyTheme(name string) {
+	m.themeName = name
+	m.styles = theme.NewStyles(theme.GetTheme(name))
+	m.spinner.Style = m.styles.Spinner
+	m.pairList.Styles.Title = m.styles.ListTitle
+}
+
+func (m Model) handleSettingsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	f := &m.settings
+	switch {
+	case key.Matches(msg, m.keys.Down, m.keys.Focus):
+		f.focus = (f.focus + 1) % fieldCount
+	case key.Matches(msg, m.keys.Up, m.keys.PrevTab):
+		f.focus = (f.focus + fieldCount - 1) % fieldCount
+	case key.Matches(msg, m.keys.More):
+		m.changeSetting(1)
+	case key.Matches(msg, m.keys.Less):
+		m.changeSetting(-1)
+	case key.Matches(msg, m.keys.Confirm):
+		return m.saveSettings()
+	}
+	return m, nil
+}
+
+// closeSettings leaves the settings view, discarding unsaved changes.
+func (m *Model) closeSettings() {
+	m.applyTheme(m.settings.original)
+	m.view = ViewMain
+}
+
+// changeSetting moves the focused setting to its next or previous value.
+func (m *Model) changeSetting(step int) {
+	f := &m.settings
+	switch f.focus {
+	case fieldTheme:
+		f.cfg.Theme = cycle(f.themes, f.cfg.Theme, step)
+		m.applyTheme(f.cfg.Theme)
+	case fieldExpiry:
+		f.cfg.SessionExpiry = cycle(expiryOptions, f.cfg.SessionExpiry, step)
+	case fieldRecent:
+		f.cfg.RecentSize = min(max(f.cfg.RecentSize+step, 1), maxRecentSize)
+	case fieldStorage:
+		f.storage = (f.storage + step + len(storageOptions)) % len(storageOptions)
+	case fieldHook:
+		f.hook = !f.hook
+	case fieldReminder:
+		on := !f.cfg.Reminder()
+		f.cfg.BranchReminder = &on
+	}
+}
+
+// cycle returns the option step places after current, wrapping around.
+// A current value that isn't an option is treated as coming before the first.
+func cycle(options []string, current string, step int) string {
+	i := slices.Index(options, current)
+	if i < 0 {
+		if step > 0 {
+			return options[0]
+		}
+		return options[len(options)-1]
+	}
+	return options[(i+step+len(options))%len(options)]
+}
+
+// storageIndex returns the storage option for a backend.
+func storageIndex(s config.Storage) int {
+	for i, o := range storageOptions {
+		if option, err := config.NewStorage(o.name, o.scope); err == nil && option.Name() == s.Name() {
+			return i
+		}
+	}
+	return 0
+}
+
+// saveSettings writes the settings to the global config, and the storage to
+// the repository's, and applies them.
+func (m Model) saveSettings() (tea.Model, tea.Cmd) {
+	f := m.settings
+
+	option := storageOptions[f.storage]
+	storage, err := config.NewStorage(option.name, option.scope)
+	if err != nil {
+		return m.notifyErr(err, nil)
+	}
+	if err := config.SwitchStorage(storage); err != nil {
+		return m.notifyErr(fmt.Errorf("failed to move pairs: %w", err), nil)
+	}
+
+	// Keep settings the view doesn't show, such as key bindings
+	cfg, err := settings.Update(func(cfg *settings.Config) {
+		cfg.Theme = f.cfg.Theme
+		cfg.SessionExpiry = f.cfg.SessionExpiry
+		cfg.RecentSize = f.cfg.RecentSize
+		cfg.BranchReminder = f.cfg.BranchReminder
+	})
+	if err != nil {
+		return m.notifyErr(fmt.Errorf("failed to save settings: %w", err), nil)
+	}
+	config.SetRecentSize(cfg.RecentSize)
+	hook.SetBranchReminder(cfg.Reminder())
+
+	switch {
+	case f.hook && (!m.hookInstalled || !m.hookCurrent):
+		err = hook.Install()
+	case !f.hook && m.hookInstalled:
+		err = hook.Uninstall()
+	}
+	if err != nil {
+		return m.notifyErr(err, nil)
+	}
+	m.hookInstalled = hook.IsInstalled()
+	m.hookCurrent = hook.IsCurrent()
+
+	m.settings.original = cfg.Theme
+	m.view = ViewMain
+	m, cmd := m.notify(severitySuccess, "Settings saved", nil)
+	return m, tea.Batch(cmd, loadPairs)
+}
+
+// settingValue describes the form's value of a setting.
+func (f settingsForm) settingValue(field settingField) string {
+	switch field {
+	case fieldTheme:
+		return f.cfg.Theme
+	case fieldExpiry:
+		if f.cfg.SessionExpiry == "" {
+			return "never"
+		}
+		if f.cfg.SessionExpiry == "168h" {
+			return "1 week"
+		}
+		return f.cfg.SessionExpiry
+	case fieldRecent:
+		return strconv.Itoa(f.cfg.RecentSize) + " pairs"
+	case fieldStorage:
+		return storageOptions[f.storage].label
+	case fieldHook:
+		if f.hook {
+			return "installed"
+		}
+		return "not installed"
+	case fieldReminder:
+		if f.cfg.Reminder() {
+			return "on"
+		}
+		return "off"
+	}
+	return ""
+}
+
+// settingLabels name the settings, and settingHelp describes them.
+var (
+	settingLabels = []string{"Theme", "Session expiry", "Recent list", "Storage", "Commit hook", "Branch reminder"}
+	settingHelp   = []string{
+		"Colors used by gh-pair. Changes are previewed as you choose.",
+		"Clear pairs left unchanged this long when gh-pair next runs.",
+		"How many unpinned recent pairs to remember.",
+		"Where this repository's pairs are kept. Pairs are moved when saved.",
+		"Add co-author trailers to commits in this repository.",
+		"List the pairs after switching branches (post-checkout hook).",
+	}
+)
+
+func (m Model) settingsView() string {
+	f := m.settings
+	var b strings.Builder
+
+	b.WriteString(m.styles.Title.Render("⚙ Settings"))
+	b.WriteString("\n\n")
+
+	for i, label := range settingLabels {
+		field := settingField(i)
+		value := f.settingValue(field)
+		row := fmt.Sprintf("  %-16s   %s", label, value)
+		if field == f.focus {
+			row = m.styles.HelpKey.Render(fmt.Sprintf("> %-16s ‹ %s ›", label, value))
+		}
+		b.WriteString(m.zones.mark("settings:"+strconv.Itoa(i), row))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.styles.Dim.Render(settingHelp[f.focus]))
+	b.WriteString("\n\n")
+	b.WriteString(m.styles.Dim.Render(hints(firstKey(m.keys.Up)+"/"+firstKey(m.keys.Down)+": navigate", firstKey(m.keys.Less)+"/"+firstKey(m.keys.More)+": change",
+		hint(m.keys.Confirm, "save"), hint(m.keys.Back, "cancel"))))
+
+	return m.styles.Box.Render(b.String())
+}
@@ -0,0 +1,161 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// severity is how much attention a notification needs.
+type severity int
+
+const (
+	severitySuccess severity = iota
+	severityWarning
+	severityError
+)
+
+// maxToasts is how many notifications are shown at once.
+const maxToasts = 3
+
+// toastDurations is how long notifications of each severity stay up.
+var toastDurations = map[severity]time.Duration{
+	severitySuccess: 3 * time.Second,
+	severityWarning: 5 * time.Second,
+	severityError:   8 * time.Second,
+}
+
+// toast is a notification shown below the view until it expires.
+type toast struct {
+	id       int
+	severity severity
+	text     string
+	retry    tea.Cmd // runs the failed command again, if it can be retried
+	scope    string  // what the user must still be looking at to retry
+}
+
+// toastExpiredMsg dismisses a notification once its time is up.
+type toastExpiredMsg struct {
+	id int
+}
+
+// notify shows a notification and schedules its dismissal.
+func (m Model) notify(sev severity, text string, retry tea.Cmd) (Model, tea.Cmd) {
+	m.toastID++
+	m.toasts = append(m.toasts, toast{id: m.toastID, severity: sev, text: text, retry: retry})
+	if len(m.toasts) > maxToasts {
+		m.toasts = m.toasts[len(m.toasts)-maxToasts:]
+	}
+
+	id := m.toastID
+	return m, tea.Tick(toastDurations[sev], func(time.Time) tea.Msg {
+		return toastExpiredMsg{id: id}
+	})
+}
+
+// notifyErr shows an error, which the retry key runs again if retry is set.
+func (m Model) notifyErr(err error, retry tea.Cmd) (Model, tea.Cmd) {
+	return m.notify(severityError, err.Error(), retry)
+}
+
+// notifyErrIn shows an error whose retry only applies while the user is
+// still looking at scope, such as the search that failed.
+func (m Model) notifyErrIn(scope string, err error, retry tea.Cmd) (Model, tea.Cmd) {
+	m, cmd := m.notifyErr(err, retry)
+	m.toasts[len(m.toasts)-1].scope = scope
+	return m, cmd
+}
+
+// Retry scopes name what a failed command loaded, so it isn't retried once
+// the user has moved on to another view, query or team.
+const (
+	teamsScope       = "teams"
+	suggestionsScope = "suggestions"
+)
+
+func searchScope(query string) string { return "search:" + query }
+
+func teamScope(org, slug string) string { return "team:" + org + "/" + slug }
+
+// retryScope returns the scope of what the user is looking at now.
+func (m Model) retryScope() string {
+	switch m.view {
+	case ViewSearch:
+		return searchScope(m.lastQuery)
+	case ViewTeams:
+		return teamsScope
+	case ViewTeamMembers:
+		if m.selectedTeam != nil {
+			return teamScope(m.selectedTeam.Org, m.selectedTeam.Slug)
+		}
+	case ViewSuggestions:
+		return suggestionsScope
+	}
+	return ""
+}
+
+// dismissToast removes a notification.
+func (m *Model) dismissToast(id int) {
+	for i, t := range m.toasts {
+		if t.id == id {
+			m.toasts = append(m.toasts[:i:i], m.toasts[i+1:]...)
+			return
+		}
+	}
+}
+
+// retryable returns the newest notification that can be retried from
+// where the user is now.
+func (m Model) retryable() (toast, bool) {
+	scope := m.retryScope()
+	for i := len(m.toasts) - 1; i >= 0; i-- {
+		t := m.toasts[i]
+		if t.retry != nil && (t.scope == "" || t.scope == scope) {
+			return t, true
+		}
+	}
+	return toast{}, false
+}
+
+// retry runs the newest failed command again.
+func (m Model) retry() (tea.Model, tea.Cmd) {
+	t, ok := m.retryable()
+	if !ok {
+		return m, nil
+	}
+	m.dismissToast(t.id)
+	m.loading = true
+	return m, t.retry
+}
+
+// toastsView renders the notifications, newest last.
+func (m Model) toastsView() string {
+	if len(m.toasts) == 0 {
+		return ""
+	}
+
+	retryable, canRetry := m.retryable()
+	lines := make([]string, len(m.toasts))
+	for i, t := range m.toasts {
+		var line string
+		switch t.severity {
+		case severityError:
+			line = m.styles.Error.Render("✗ " + t.text)
+		case severityWarning:
+			line = m.styles.Warning.Render("⚠ " + t.text)
+		default:
+			line = m.styles.Success.Render("✓ " + t.text)
+		}
+		if canRetry && t.id == retryable.id {
+			// The retry key is typed as text while an input has focus
+			retry := hint(m.keys.Retry, "retry")
+			if m.typing() {
+				retry = firstKey(m.keys.Focus) + " then " + retry
+			}
+			line += m.styles.Dim.Render(" • " + retry)
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
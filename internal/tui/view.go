@@ -2,11 +2,23 @@ package tui
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
 )
 
-// View renders the TUI.
+// View renders the TUI with its notifications, noting where its clickable
+// parts were drawn.
 func (m Model) View() string {
+	view := m.render()
+	if toasts := m.toastsView(); toasts != "" {
+		view += "\n" + toasts
+	}
+	return m.zones.scan(view)
+}
+
+func (m Model) render() string {
 	if m.width == 0 {
 		return "Loading..."
 	}
@@ -15,11 +27,17 @@ func (m Model) View() string {
 	case ViewHelp:
 		return m.helpView()
 	case ViewSearch:
-		return m.searchView()
+		return m.withSidebar(m.searchView())
 	case ViewTeams:
-		return m.teamsView()
+		return m.withSidebar(m.teamsView())
 	case ViewTeamMembers:
-		return m.teamMembersView()
+		return m.withSidebar(m.teamMembersView())
+	case ViewSuggestions:
+		return m.withSidebar(m.suggestionsView())
+	case ViewEdit:
+		return m.editView()
+	case ViewSettings:
+		return m.settingsView()
 	default:
 		return m.mainView()
 	}
@@ -35,13 +53,11 @@ func (m Model) mainView() string {
 	// Hook status
 	if !m.hookInstalled {
 		b.WriteString(m.styles.Error.Render("⚠ Hook not installed"))
-		b.WriteString(m.styles.Dim.Render(" - press 'i' to install"))
+		b.WriteString(m.styles.Dim.Render(" - press '" + firstKey(m.keys.InstallHook) + "' to install"))
 		b.WriteString("\n\n")
-	}
-
-	// Error display
-	if m.err != nil {
-		b.WriteString(m.styles.Error.Render("Error: " + m.err.Error()))
+	} else if !m.hookCurrent {
+		b.WriteString(m.styles.Warning.Render("⚠ Hook outdated"))
+		b.WriteString(m.styles.Dim.Render(" - press '" + firstKey(m.keys.InstallHook) + "' to update"))
 		b.WriteString("\n\n")
 	}
 
@@ -54,17 +70,26 @@ func (m Model) mainView() string {
 
 	// Pair list or empty state
 	if len(m.pairs) == 0 {
-		b.WriteString(m.styles.Subtitle.Render("No pairs configured"))
+		if m.branch != "" {
+			b.WriteString(m.styles.Subtitle.Render("No pairs configured on " + m.branch))
+		} else {
+			b.WriteString(m.styles.Subtitle.Render("No pairs configured"))
+		}
 		b.WriteString("\n")
-		b.WriteString(m.styles.Dim.Render("Press 'a' to search users or 't' to browse teams"))
+		b.WriteString(m.styles.Dim.Render(fmt.Sprintf("Press '%s' to search users or '%s' to browse teams",
+			firstKey(m.keys.Search), firstKey(m.keys.Teams))))
 		b.WriteString("\n")
 	} else {
 		b.WriteString(m.pairList.View())
 	}
 
-	// Help footer
+	// Help footer, or the pending confirmation
 	b.WriteString("\n")
-	b.WriteString(m.helpFooter())
+	if m.confirmPrompt != "" {
+		b.WriteString(m.confirmView())
+	} else {
+		b.WriteString(m.helpFooter())
+	}
 
 	return b.String()
 }
@@ -73,101 +98,112 @@ func (m Model) searchView() string {
 	var b strings.Builder
 
 	b.WriteString(m.styles.Title.Render("🔍 Add Pair"))
+	b.WriteString("\n")
+	b.WriteString(m.tabsView())
 	b.WriteString("\n\n")
 
 	// Search input
 	b.WriteString(m.searchInput.View())
 	b.WriteString("\n\n")
 
-	if m.loading {
+	// Local matches stay listed while GitHub is searched
+	if m.loading && len(m.searchList.Items()) == 0 {
 		b.WriteString(m.spinner.View())
 		b.WriteString(" Searching...\n")
 		return b.String()
 	}
 
-	if m.err != nil {
-		b.WriteString(m.styles.Error.Render("Error: " + m.err.Error()))
-		b.WriteString("\n\n")
-	}
-
 	// Results label
-	if len(m.searchResults) > 0 {
+	switch {
+	case m.loading:
+		b.WriteString(m.spinner.View() + m.styles.Dim.Render(" Searching GitHub..."))
+	case len(m.searchResults) > 0:
 		b.WriteString(m.styles.Dim.Render("Search Results:"))
-	} else if len(m.searchList.Items()) > 0 {
+	case strings.TrimSpace(m.searchInput.Value()) != "" && len(m.searchList.Items()) > 0:
+		b.WriteString(m.styles.Dim.Render("Matching Recent / Collaborators:"))
+	case len(m.searchList.Items()) > 0:
 		b.WriteString(m.styles.Dim.Render("Recent / Collaborators:"))
 	}
 	b.WriteString("\n")
 
 	// Search results list
-	b.WriteString(m.searchList.View())
+	b.WriteString(m.withProfile(m.searchList.View()))
 
 	// Help footer
 	b.WriteString("\n")
-	b.WriteString(m.styles.Dim.Render("Enter: add • Tab: switch focus • Esc: cancel"))
+	k := m.keys
+	if !m.searchInput.Focused() && len(m.searchResults) == 0 {
+		b.WriteString(m.styles.Dim.Render(hints(m.addHint(), hint(k.Select, "select"), hint(k.Pin, "pin/unpin recent"),
+			hint(k.Forget, "forget recent"), hint(k.Focus, "switch focus"), hint(k.Back, "cancel"))))
+	} else if !m.searchInput.Focused() {
+		b.WriteString(m.styles.Dim.Render(hints(m.addHint(), hint(k.Select, "select"), hint(k.Focus, "switch focus"),
+			m.tabHint(), hint(k.Back, "cancel"))))
+	} else {
+		b.WriteString(m.styles.Dim.Render(hints(m.addHint(), hint(k.Focus, "switch focus"), m.tabHint(),
+			hint(k.Back, "cancel"))))
+	}
 
 	return b.String()
 }
 
 func (m Model) helpView() string {
-	help := []struct {
-		key  string
-		desc string
-	}{
-		{"a, /", "Search GitHub users"},
-		{"t", "Browse your teams"},
-		{"d, Delete", "Remove selected pair"},
-		{"c", "Clear all pairs"},
-		{"i", "Install git hook"},
-		{"↑/↓", "Navigate list"},
-		{"Enter", "Select / Confirm"},
-		{"Esc", "Cancel / Back"},
-		{"?", "Toggle help"},
-		{"q", "Quit"},
-	}
-
 	var content strings.Builder
 	content.WriteString(m.styles.Title.Render("Keyboard Shortcuts"))
 	content.WriteString("\n\n")
 
-	for _, h := range help {
+	entries := m.keys.entries()
+	width := 12
+	for _, e := range entries {
+		width = max(width, len([]rune(e.binding.Help().Key)))
+	}
+
+	for _, e := range entries {
 		content.WriteString(fmt.Sprintf("%s  %s\n",
-			m.styles.HelpKey.Render(fmt.Sprintf("%-12s", h.key)),
-			m.styles.HelpDesc.Render(h.desc)))
+			m.styles.HelpKey.Render(fmt.Sprintf("%-*s", width, e.binding.Help().Key)),
+			m.styles.HelpDesc.Render(e.desc)))
 	}
 
 	content.WriteString("\n")
-	content.WriteString(m.styles.Dim.Render("Press Esc or ? to close"))
+	content.WriteString(m.styles.Dim.Render(fmt.Sprintf("Press %s or %s to close", firstKey(m.keys.Back), firstKey(m.keys.Help))))
 
 	return m.styles.Box.Render(content.String())
 }
 
 func (m Model) helpFooter() string {
-	keys := []struct {
-		key  string
-		desc string
-	}{
-		{"a", "search"},
-		{"t", "teams"},
-		{"d", "remove"},
-		{"c", "clear"},
-		{"?", "help"},
-		{"q", "quit"},
-	}
+	k := m.keys
+	bindings := []key.Binding{k.Search, k.Teams, k.Suggest, k.Remove, k.Edit, k.Clear, k.Undo, k.Settings, k.Help, k.Quit}
 
 	var parts []string
-	for _, k := range keys {
+	for _, b := range bindings {
 		parts = append(parts, fmt.Sprintf("%s %s",
-			m.styles.HelpKey.Render(k.key),
-			m.styles.Dim.Render(k.desc)))
+			m.styles.HelpKey.Render(firstKey(b)),
+			m.styles.Dim.Render(b.Help().Desc)))
 	}
 
 	return strings.Join(parts, m.styles.Dim.Render(" • "))
 }
 
+// hint describes what a binding does in a view's footer.
+func hint(b key.Binding, desc string) string {
+	return firstKey(b) + ": " + desc
+}
+
+// tabHint describes the keys that switch tabs.
+func (m Model) tabHint() string {
+	return firstKey(m.keys.PrevTab) + "/" + firstKey(m.keys.NextTab) + ": switch tab"
+}
+
+// hints joins footer hints.
+func hints(h ...string) string {
+	return strings.Join(h, " • ")
+}
+
 func (m Model) teamsView() string {
 	var b strings.Builder
 
 	b.WriteString(m.styles.Title.Render("👥 Your Teams"))
+	b.WriteString("\n")
+	b.WriteString(m.tabsView())
 	b.WriteString("\n\n")
 
 	// Filter input
@@ -176,15 +212,14 @@ func (m Model) teamsView() string {
 
 	if m.loading {
 		b.WriteString(m.spinner.View())
-		b.WriteString(" Loading teams...\n")
+		if len(m.teams) > 0 {
+			b.WriteString(" Loading team members...\n")
+		} else {
+			b.WriteString(" Loading teams...\n")
+		}
 		return b.String()
 	}
 
-	if m.err != nil {
-		b.WriteString(m.styles.Error.Render("Error: " + m.err.Error()))
-		b.WriteString("\n\n")
-	}
-
 	if len(m.filteredTeams) == 0 && len(m.teams) > 0 {
 		b.WriteString(m.styles.Dim.Render("No teams match your filter"))
 		b.WriteString("\n")
@@ -198,11 +233,25 @@ func (m Model) teamsView() string {
 	}
 
 	b.WriteString("\n")
-	b.WriteString(m.styles.Dim.Render("Enter: select team • Tab: filter • Esc: back"))
+	if m.confirmPrompt != "" {
+		b.WriteString(m.confirmView())
+	} else if m.searchInput.Focused() {
+		b.WriteString(m.styles.Dim.Render(hints(hint(m.keys.Confirm, "select team"), hint(m.keys.Focus, "focus list"),
+			hint(m.keys.Back, "back"))))
+	} else {
+		b.WriteString(m.styles.Dim.Render(hints(hint(m.keys.Confirm, "select team"), hint(m.keys.AddTeam, "add whole team"),
+			hint(m.keys.Focus, "filter"), hint(m.keys.Back, "back"))))
+	}
 
 	return b.String()
 }
 
+// confirmView renders the pending confirmation prompt.
+func (m Model) confirmView() string {
+	return m.styles.Warning.Render(m.confirmPrompt) +
+		m.styles.Dim.Render(" y to confirm, any other key to cancel")
+}
+
 func (m Model) teamMembersView() string {
 	var b strings.Builder
 
@@ -211,6 +260,8 @@ func (m Model) teamMembersView() string {
 		teamName = m.selectedTeam.Name
 	}
 	b.WriteString(m.styles.Title.Render("👥 " + teamName + " Members"))
+	b.WriteString("\n")
+	b.WriteString(m.tabsView())
 	b.WriteString("\n\n")
 
 	// Filter input
@@ -223,11 +274,6 @@ func (m Model) teamMembersView() string {
 		return b.String()
 	}
 
-	if m.err != nil {
-		b.WriteString(m.styles.Error.Render("Error: " + m.err.Error()))
-		b.WriteString("\n\n")
-	}
-
 	if len(m.filteredTeamMembers) == 0 && len(m.teamMembers) > 0 {
 		b.WriteString(m.styles.Dim.Render("No members match your filter"))
 		b.WriteString("\n")
@@ -235,11 +281,89 @@ func (m Model) teamMembersView() string {
 		b.WriteString(m.styles.Dim.Render("No members found"))
 		b.WriteString("\n")
 	} else {
-		b.WriteString(m.searchList.View())
+		b.WriteString(m.withProfile(m.searchList.View()))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.styles.Dim.Render(hints(m.addHint(), hint(m.keys.Select, "select"), hint(m.keys.Focus, "switch focus"),
+		hint(m.keys.Back, "back to teams"))))
+
+	return b.String()
+}
+
+// addHint describes what enter adds in the search and team member views.
+func (m Model) addHint() string {
+	if len(m.selected) > 0 {
+		return hint(m.keys.Confirm, fmt.Sprintf("add %d selected", len(m.selected)))
+	}
+	return hint(m.keys.Confirm, "add")
+}
+
+// tabsView renders the tab bar shared by the search, teams and suggestions views.
+func (m Model) tabsView() string {
+	var parts []string
+	for i, name := range tabNames {
+		zone := "tab:" + strconv.Itoa(i)
+		if SearchTab(i) == m.searchTab {
+			parts = append(parts, m.zones.mark(zone, m.styles.HelpKey.Render("["+name+"]")))
+		} else {
+			parts = append(parts, m.zones.mark(zone, m.styles.Dim.Render(" "+name+" ")))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func (m Model) suggestionsView() string {
+	var b strings.Builder
+
+	b.WriteString(m.styles.Title.Render("🔄 Suggested Pairs"))
+	b.WriteString("\n")
+	b.WriteString(m.tabsView())
+	b.WriteString("\n\n")
+
+	if m.loading {
+		b.WriteString(m.spinner.View())
+		b.WriteString(" Reading pairing history...\n")
+		return b.String()
+	}
+
+	if len(m.suggestionList.Items()) == 0 {
+		b.WriteString(m.styles.Subtitle.Render("No suggestions"))
+		b.WriteString("\n")
+		b.WriteString(m.styles.Dim.Render("Suggestions come from the members of your GitHub teams"))
+		b.WriteString("\n")
+	} else {
+		b.WriteString(m.withProfile(m.suggestionList.View()))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.styles.Dim.Render(hints(hint(m.keys.Confirm, "add"), m.tabHint(), hint(m.keys.Back, "back"))))
+
+	return b.String()
+}
+
+func (m Model) editView() string {
+	var b strings.Builder
+
+	b.WriteString(m.styles.Title.Render("✏️  Edit @" + m.editing.Username))
+	b.WriteString("\n\n")
+
+	if m.editErr != nil {
+		b.WriteString(m.styles.Error.Render("Error: " + m.editErr.Error()))
+		b.WriteString("\n\n")
 	}
 
+	var content strings.Builder
+	for _, input := range m.editInputs {
+		content.WriteString(input.View())
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+	content.WriteString(m.styles.Dim.Render("Saved for this user in every repository"))
+	b.WriteString(m.styles.Box.Render(content.String()))
+
 	b.WriteString("\n")
-	b.WriteString(m.styles.Dim.Render("Enter: add • Tab: switch focus • Esc: back to teams"))
+	b.WriteString(m.styles.Dim.Render(hints(hint(m.keys.Focus, "next field"), hint(m.keys.Confirm, "save"), hint(m.keys.Back, "cancel"))))
 
 	return b.String()
 }
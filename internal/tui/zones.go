@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// zoneMarker matches the markers mark puts around clickable parts of the
+// view. They are CSI sequences nothing else uses, so lipgloss measures them
+// as zero width while the view is laid out.
+var zoneMarker = regexp.MustCompile(`\x1b\[(\d+)z`)
+
+// zoneRect is where a marked part of the view was drawn, in cells.
+type zoneRect struct {
+	x1, y1, x2, y2 int
+}
+
+// zoneMap finds where the clickable parts of the view ended up, so mouse
+// clicks can be matched to them. Views wrap parts in mark, then View strips
+// the markers with scan, recording each part's bounds.
+type zoneMap struct {
+	ids   map[string]int
+	names []string
+	rects map[string]zoneRect
+}
+
+func newZoneMap() *zoneMap {
+	return &zoneMap{ids: make(map[string]int), rects: make(map[string]zoneRect)}
+}
+
+// mark wraps s in the markers of the named zone.
+func (z *zoneMap) mark(name, s string) string {
+	id, ok := z.ids[name]
+	if !ok {
+		// Zones come in start and end pairs of ids
+		id = len(z.names) * 2
+		z.ids[name] = id
+		z.names = append(z.names, name)
+	}
+	return "\x1b[" + strconv.Itoa(id) + "z" + s + "\x1b[" + strconv.Itoa(id+1) + "z"
+}
+
+// scan records the bounds of every marked zone in view and returns view
+// without the markers.
+func (z *zoneMap) scan(view string) string {
+	clear(z.rects)
+	open := make(map[int]zoneRect)
+
+	lines := strings.Split(view, "\n")
+	for y, line := range lines {
+		var b strings.Builder
+		x := 0
+		last := 0
+		for _, loc := range zoneMarker.FindAllStringSubmatchIndex(line, -1) {
+			text := line[last:loc[0]]
+			b.WriteString(text)
+			x += ansi.StringWidth(text)
+			last = loc[1]
+
+			id, _ := strconv.Atoi(line[loc[2]:loc[3]])
+			if id%2 == 0 {
+				open[id/2] = zoneRect{x1: x, y1: y, x2: x, y2: y}
+				continue
+			}
+			if r, ok := open[id/2]; ok && id/2 < len(z.names) {
+				r.x2, r.y2 = max(r.x2, x-1), y
+				z.rects[z.names[id/2]] = r
+				delete(open, id/2)
+			}
+		}
+		b.WriteString(line[last:])
+		x += ansi.StringWidth(line[last:])
+
+		// Zones spanning lines cover the widest of them
+		for i, r := range open {
+			r.x2 = max(r.x2, x-1)
+			open[i] = r
+		}
+		lines[y] = b.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// at returns the name of the innermost zone drawn at a cell.
+func (z *zoneMap) at(x, y int) (string, bool) {
+	var found string
+	var area int
+	for name, r := range z.rects {
+		if x < r.x1 || x > r.x2 || y < r.y1 || y > r.y2 {
+			continue
+		}
+		if a := (r.x2 - r.x1 + 1) * (r.y2 - r.y1 + 1); found == "" || a < area {
+			found, area = name, a
+		}
+	}
+	return found, found != ""
+}